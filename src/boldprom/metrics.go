@@ -0,0 +1,149 @@
+// Package boldprom exports the metrics of the Bold SDK to Prometheus.
+//
+// It lives in its own module, so the SDK does not depend on Prometheus:
+//
+//	metrics, err := boldprom.New(prometheus.DefaultRegisterer, boldprom.Config{})
+//	client := sdk.NewClient(sdk.ClientConfig{ApiKey: apiKey, Metrics: metrics})
+//	handler := webhook.NewHandler(webhook.HandlerConfig{SecretKey: secretKey, Metrics: metrics})
+package boldprom
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/PChaparro/bold-co-sdk/src/sdk"
+	"github.com/PChaparro/bold-co-sdk/src/webhook"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// DefaultNamespace is the namespace of the metrics when Config.Namespace is not provided.
+const DefaultNamespace = "bold"
+
+// Config contains the options of the Metrics.
+type Config struct {
+	// Namespace is the prefix of the metric names.
+	// If not provided, it defaults to DefaultNamespace.
+	Namespace string
+
+	// Buckets are the buckets of the request duration histogram, in seconds.
+	// If not provided, it defaults to prometheus.DefBuckets.
+	Buckets []float64
+
+	// ConstLabels are added to every metric (e.g., to identify the merchant).
+	ConstLabels prometheus.Labels
+}
+
+// Metrics implements sdk.Metrics and webhook.Metrics with Prometheus collectors:
+//   - <namespace>_requests_total{operation, status}: requests sent to the Bold
+//     API, by the status code of their last response ("error" if they failed without one).
+//   - <namespace>_request_duration_seconds{operation}: duration of the requests, including retries.
+//   - <namespace>_request_retries_total{operation}: retried attempts.
+//   - <namespace>_cache_lookups_total{operation, result}: cache lookups, by result
+//     ("hit" or "miss"). The hit ratio is the rate of hits over the rate of lookups.
+//   - <namespace>_webhook_verification_failures_total{reason}: rejected webhook notifications.
+type Metrics struct {
+	requests             *prometheus.CounterVec
+	duration             *prometheus.HistogramVec
+	retries              *prometheus.CounterVec
+	cacheLookups         *prometheus.CounterVec
+	verificationFailures *prometheus.CounterVec
+}
+
+// Verify that Metrics implements the metrics interfaces of the SDK.
+var (
+	_ sdk.Metrics     = (*Metrics)(nil)
+	_ webhook.Metrics = (*Metrics)(nil)
+)
+
+// New creates the collectors and registers them in the given registerer.
+func New(registerer prometheus.Registerer, config Config) (*Metrics, error) {
+	if config.Namespace == "" {
+		config.Namespace = DefaultNamespace
+	}
+	if len(config.Buckets) == 0 {
+		config.Buckets = prometheus.DefBuckets
+	}
+
+	metrics := &Metrics{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   config.Namespace,
+			Name:        "requests_total",
+			Help:        "Requests sent to the Bold API, by operation and status code.",
+			ConstLabels: config.ConstLabels,
+		}, []string{"operation", "status"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace:   config.Namespace,
+			Name:        "request_duration_seconds",
+			Help:        "Duration of the requests sent to the Bold API, including retries.",
+			Buckets:     config.Buckets,
+			ConstLabels: config.ConstLabels,
+		}, []string{"operation"}),
+		retries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   config.Namespace,
+			Name:        "request_retries_total",
+			Help:        "Retried attempts of the requests sent to the Bold API.",
+			ConstLabels: config.ConstLabels,
+		}, []string{"operation"}),
+		cacheLookups: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   config.Namespace,
+			Name:        "cache_lookups_total",
+			Help:        "Lookups of the response cache, by result (hit or miss).",
+			ConstLabels: config.ConstLabels,
+		}, []string{"operation", "result"}),
+		verificationFailures: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   config.Namespace,
+			Name:        "webhook_verification_failures_total",
+			Help:        "Webhook notifications rejected before being processed, by reason.",
+			ConstLabels: config.ConstLabels,
+		}, []string{"reason"}),
+	}
+
+	collectors := metrics.collectors()
+	for i, collector := range collectors {
+		if err := registerer.Register(collector); err != nil {
+			// Do not leave the metrics partially registered
+			for _, registered := range collectors[:i] {
+				registerer.Unregister(registered)
+			}
+			return nil, err
+		}
+	}
+
+	return metrics, nil
+}
+
+// ObserveRequest implements sdk.Metrics.
+func (m *Metrics) ObserveRequest(operation string, statusCode int, duration time.Duration) {
+	status := "error"
+	if statusCode != 0 {
+		status = strconv.Itoa(statusCode)
+	}
+
+	m.requests.WithLabelValues(operation, status).Inc()
+	m.duration.WithLabelValues(operation).Observe(duration.Seconds())
+}
+
+// ObserveRetry implements sdk.Metrics.
+func (m *Metrics) ObserveRetry(operation string) {
+	m.retries.WithLabelValues(operation).Inc()
+}
+
+// ObserveCacheLookup implements sdk.Metrics.
+func (m *Metrics) ObserveCacheLookup(operation string, hit bool) {
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+
+	m.cacheLookups.WithLabelValues(operation, result).Inc()
+}
+
+// ObserveVerificationFailure implements webhook.Metrics.
+func (m *Metrics) ObserveVerificationFailure(reason string) {
+	m.verificationFailures.WithLabelValues(reason).Inc()
+}
+
+// collectors returns the collectors of the metrics.
+func (m *Metrics) collectors() []prometheus.Collector {
+	return []prometheus.Collector{m.requests, m.duration, m.retries, m.cacheLookups, m.verificationFailures}
+}
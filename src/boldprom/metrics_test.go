@@ -0,0 +1,82 @@
+package boldprom_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/PChaparro/bold-co-sdk/src/boldprom"
+	"github.com/PChaparro/bold-co-sdk/src/boldtest"
+	"github.com/PChaparro/bold-co-sdk/src/sdk"
+	"github.com/PChaparro/bold-co-sdk/src/webhook"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetrics(t *testing.T) {
+	ctx := context.Background()
+
+	registry := prometheus.NewPedanticRegistry()
+	metrics, err := boldprom.New(registry, boldprom.Config{})
+	require.NoError(t, err)
+
+	server := boldtest.NewServer(boldtest.Config{})
+	defer server.Close()
+
+	client := sdk.NewClient(sdk.ClientConfig{
+		ApiKey:      boldtest.DefaultAPIKey,
+		BaseURL:     server.URL,
+		RetryPolicy: &sdk.RetryPolicy{MaxAttempts: 2, RetryableStatusCodes: []int{http.StatusServiceUnavailable}},
+		Cache:       &sdk.CacheConfig{Cache: sdk.NewMemoryCache()},
+		Metrics:     metrics,
+	})
+
+	server.InjectFault(boldtest.Fault{Path: "/online/link/v1/payment_methods", StatusCode: http.StatusServiceUnavailable, Times: 1})
+	for range 3 {
+		_, err := client.GetPaymentMethodsForPaymentLink(ctx)
+		require.NoError(t, err)
+	}
+	_, err = client.GetPaymentLinkData(ctx, "LNK_MISSING")
+	require.ErrorIs(t, err, sdk.ErrNotFound)
+
+	handler := webhook.NewHandler(webhook.HandlerConfig{SecretKey: "secret", Metrics: metrics})
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/webhooks/bold", strings.NewReader(`{}`)))
+
+	expected := `
+# HELP bold_cache_lookups_total Lookups of the response cache, by result (hit or miss).
+# TYPE bold_cache_lookups_total counter
+bold_cache_lookups_total{operation="get available payment methods for payment link",result="hit"} 2
+bold_cache_lookups_total{operation="get available payment methods for payment link",result="miss"} 1
+# HELP bold_request_retries_total Retried attempts of the requests sent to the Bold API.
+# TYPE bold_request_retries_total counter
+bold_request_retries_total{operation="get available payment methods for payment link"} 1
+# HELP bold_requests_total Requests sent to the Bold API, by operation and status code.
+# TYPE bold_requests_total counter
+bold_requests_total{operation="get available payment methods for payment link",status="200"} 1
+bold_requests_total{operation="get data of payment link",status="404"} 1
+# HELP bold_webhook_verification_failures_total Webhook notifications rejected before being processed, by reason.
+# TYPE bold_webhook_verification_failures_total counter
+bold_webhook_verification_failures_total{reason="missing_signature"} 1
+`
+	err = testutil.GatherAndCompare(registry, strings.NewReader(expected),
+		"bold_cache_lookups_total",
+		"bold_request_retries_total",
+		"bold_requests_total",
+		"bold_webhook_verification_failures_total",
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, testutil.CollectAndCount(registry, "bold_request_duration_seconds"))
+
+	t.Run("registering twice fails", func(t *testing.T) {
+		_, err := boldprom.New(registry, boldprom.Config{})
+		assert.Error(t, err)
+
+		// Other namespaces can be registered
+		_, err = boldprom.New(registry, boldprom.Config{Namespace: "bold_secondary"})
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,115 @@
+package boldtest
+
+import (
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Fault is an error injected in the responses of the server.
+type Fault struct {
+	// Method restricts the fault to the requests with this HTTP method.
+	// If empty, it matches every method.
+	Method string
+
+	// Path restricts the fault to the requests whose path starts with this
+	// prefix (e.g., "/online/link/v1"). If empty, it matches every path.
+	Path string
+
+	// Times is the number of requests affected by the fault.
+	// If zero, the fault affects every matching request.
+	Times int
+
+	// Delay is applied before responding, or before dropping the connection.
+	// Requests whose context is done during the delay are not answered.
+	Delay time.Duration
+
+	// StatusCode is the status code of the response. If zero, the request is
+	// handled normally after the delay (useful to inject latency only).
+	StatusCode int
+
+	// Headers are added to the response (e.g., Retry-After).
+	Headers map[string]string
+
+	// Body is the body of the response. If empty, a Bold error body is used.
+	Body string
+
+	// DropConnection closes the connection without responding.
+	DropConnection bool
+
+	hits int
+}
+
+// InjectFault adds a fault to the server. Faults are matched in the order
+// they were injected; each request is affected by one fault at most.
+func (s *Server) InjectFault(fault Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = append(s.faults, &fault)
+}
+
+// ClearFaults removes every injected fault.
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = nil
+}
+
+// matchFault returns the fault affecting the request, if any, counting the hit.
+// It must be called with the mutex held.
+func (s *Server) matchFault(r *http.Request) *Fault {
+	for _, fault := range s.faults {
+		if fault.Method != "" && fault.Method != r.Method {
+			continue
+		}
+		if !strings.HasPrefix(r.URL.Path, fault.Path) {
+			continue
+		}
+		if fault.Times > 0 && fault.hits >= fault.Times {
+			continue
+		}
+
+		fault.hits++
+		copied := *fault
+		return &copied
+	}
+	return nil
+}
+
+// apply injects the fault in the response, reporting whether the request was answered.
+func (f *Fault) apply(w http.ResponseWriter, r *http.Request) bool {
+	if f.Delay > 0 {
+		timer := time.NewTimer(f.Delay)
+		defer timer.Stop()
+		select {
+		case <-r.Context().Done():
+			return true
+		case <-timer.C:
+		}
+	}
+
+	if f.DropConnection {
+		if hijacker, ok := w.(http.Hijacker); ok {
+			if conn, _, err := hijacker.Hijack(); err == nil {
+				_ = conn.Close()
+				return true
+			}
+		}
+		panic(http.ErrAbortHandler)
+	}
+
+	if f.StatusCode == 0 {
+		return false
+	}
+
+	for key, value := range f.Headers {
+		w.Header().Set(key, value)
+	}
+	if f.Body != "" {
+		w.WriteHeader(f.StatusCode)
+		_, _ = w.Write([]byte(f.Body))
+		return true
+	}
+	writeError(w, f.StatusCode, "INJECTED_FAULT", http.StatusText(f.StatusCode))
+	return true
+}
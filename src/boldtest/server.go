@@ -0,0 +1,397 @@
+// Package boldtest provides an in-memory implementation of the Bold API,
+// served through an httptest.Server, so code using the SDK can be tested
+// without network access or a Bold account.
+//
+// The server implements every endpoint used by the SDK, checks the API key,
+// keeps the created payment links in memory, lets tests script the binded
+// terminals and inject faults (error responses, delays and dropped connections).
+package boldtest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/PChaparro/bold-co-sdk/src/definitions"
+	"github.com/PChaparro/bold-co-sdk/src/internal/fixtures"
+)
+
+// DefaultAPIKey is the API key accepted by the server when Config.APIKey is not provided.
+const DefaultAPIKey = "test-api-key"
+
+// DefaultPaymentMethods are the payment methods for payment links (and their
+// limits, in pesos) returned when Config.PaymentMethods is not provided.
+var DefaultPaymentMethods = fixtures.PaymentMethods
+
+// DefaultIntegrationPaymentMethods are the payment methods for the integrations
+// API returned when Config.IntegrationPaymentMethods is not provided.
+var DefaultIntegrationPaymentMethods = fixtures.IntegrationPaymentMethods
+
+// Config contains the configuration options for the Server.
+type Config struct {
+	// APIKey is the only API key accepted by the server. Defaults to DefaultAPIKey.
+	APIKey string
+
+	// PaymentMethods are the payment methods for payment links. Defaults to DefaultPaymentMethods.
+	PaymentMethods definitions.PaymentMethodsMap
+
+	// IntegrationPaymentMethods are the payment methods for the integrations API.
+	// Defaults to DefaultIntegrationPaymentMethods.
+	IntegrationPaymentMethods []definitions.IntegrationPaymentMethod
+
+	// Terminals are the terminals binded to the integration.
+	// Like Bold, the server answers with HTTP 404 when there are none.
+	Terminals []Terminal
+}
+
+// Terminal is a terminal binded to the integration.
+type Terminal struct {
+	// Model is the model of the terminal (e.g., "N86").
+	Model string
+
+	// Serial is the serial of the terminal.
+	Serial string
+
+	// Name is the name of the terminal.
+	Name string
+
+	// Unavailable makes the terminal reject the payments with the
+	// "Terminal no disponible" error returned by Bold.
+	Unavailable bool
+
+	// OnPayment scripts the response of the terminal to a payment. If it returns
+	// an error, the payment is rejected with HTTP 400 and the error message.
+	OnPayment func(req definitions.CreatePaymentForIntegrationsAPIRequest) error
+}
+
+// Payment is a payment created through the integrations API.
+type Payment struct {
+	// IntegrationID is the identifier returned for the payment.
+	IntegrationID string
+
+	// Request is the request that created the payment.
+	Request definitions.CreatePaymentForIntegrationsAPIRequest
+}
+
+// Request is a request received by the server.
+type Request struct {
+	// Method is the HTTP method of the request.
+	Method string
+
+	// Path is the path of the request.
+	Path string
+
+	// Body is the raw body of the request.
+	Body []byte
+}
+
+// Server is an in-memory implementation of the Bold API. Use its URL as the
+// ClientConfig.BaseURL of the client under test.
+type Server struct {
+	*httptest.Server
+
+	apiKey string
+
+	mu                        sync.Mutex
+	paymentMethods            definitions.PaymentMethodsMap
+	integrationPaymentMethods []definitions.IntegrationPaymentMethod
+	terminals                 []Terminal
+	links                     map[string]*definitions.PaymentLinkDetails
+	payments                  []Payment
+	requests                  []Request
+	faults                    []*Fault
+	sequence                  int
+}
+
+// NewServer starts a new Server. Close it when the test finishes.
+func NewServer(config Config) *Server {
+	s := &Server{
+		apiKey:                    config.APIKey,
+		paymentMethods:            config.PaymentMethods,
+		integrationPaymentMethods: config.IntegrationPaymentMethods,
+		terminals:                 config.Terminals,
+		links:                     make(map[string]*definitions.PaymentLinkDetails),
+	}
+	if s.apiKey == "" {
+		s.apiKey = DefaultAPIKey
+	}
+	if s.paymentMethods == nil {
+		s.paymentMethods = DefaultPaymentMethods
+	}
+	if s.integrationPaymentMethods == nil {
+		s.integrationPaymentMethods = DefaultIntegrationPaymentMethods
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /online/link/v1", s.createPaymentLink)
+	mux.HandleFunc("GET /online/link/v1/payment_methods", s.getPaymentMethodsForPaymentLink)
+	mux.HandleFunc("GET /online/link/v1/{id}", s.getPaymentLinkData)
+	mux.HandleFunc("GET /payments/payment-methods", s.getPaymentMethodsForIntegrationsAPI)
+	mux.HandleFunc("GET /payments/binded-terminals", s.getBindedTerminals)
+	mux.HandleFunc("POST /payments/app-checkout", s.createPaymentForIntegrationsAPI)
+
+	s.Server = httptest.NewServer(s.middleware(mux))
+	return s
+}
+
+// middleware records the requests, applies the faults and checks the API key.
+func (s *Server) middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		s.mu.Lock()
+		s.requests = append(s.requests, Request{Method: r.Method, Path: r.URL.Path, Body: body})
+		fault := s.matchFault(r)
+		s.mu.Unlock()
+
+		if fault != nil && fault.apply(w, r) {
+			return
+		}
+
+		if r.Header.Get("Authorization") != "x-api-key "+s.apiKey {
+			writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "Invalid API key")
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// createPaymentLink implements POST /online/link/v1.
+func (s *Server) createPaymentLink(w http.ResponseWriter, r *http.Request) {
+	var req definitions.CreatePaymentLinkRequest
+	if !decodeRequest(w, r, validatePaymentLinkRequest, &req) {
+		return
+	}
+
+	s.mu.Lock()
+	s.sequence++
+	details := fixtures.NewPaymentLink(s.sequence, req)
+	id := details.ID
+	s.links[id] = &details
+	s.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, definitions.CreatePaymentLinkResponse{
+		Payload: definitions.PaymentLinkData{
+			PaymentLink: id,
+			URL:         fixtures.PaymentLinkURL(id),
+		},
+		Errors: []definitions.ErrorField{},
+	})
+}
+
+// getPaymentLinkData implements GET /online/link/v1/{id}.
+func (s *Server) getPaymentLinkData(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	details, ok := s.links[r.PathValue("id")]
+	if ok {
+		expireLink(details)
+	}
+	var response definitions.GetPaymentLinkDataResponse
+	if ok {
+		response.PaymentLinkDetails = *details
+	}
+	s.mu.Unlock()
+
+	if !ok {
+		writeError(w, http.StatusNotFound, "NOT_FOUND", "Payment link not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+// getPaymentMethodsForPaymentLink implements GET /online/link/v1/payment_methods.
+func (s *Server) getPaymentMethodsForPaymentLink(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, definitions.GetPaymentMethodsForPaymentLinkResponse{
+		Payload: definitions.PaymentMethodsData{PaymentMethods: s.paymentMethods},
+		Errors:  []definitions.ErrorField{},
+	})
+}
+
+// getPaymentMethodsForIntegrationsAPI implements GET /payments/payment-methods.
+func (s *Server) getPaymentMethodsForIntegrationsAPI(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	methods := s.integrationPaymentMethods
+	writeJSON(w, http.StatusOK, definitions.GetPaymentMethodsForIntegrationsAPIResponse{
+		Payload: definitions.IntegrationPaymentMethodsData{PaymentMethods: &methods},
+		Errors:  []definitions.ErrorField{},
+	})
+}
+
+// getBindedTerminals implements GET /payments/binded-terminals.
+func (s *Server) getBindedTerminals(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	terminals := make([]definitions.TerminalInfo, len(s.terminals))
+	for i, terminal := range s.terminals {
+		terminals[i] = definitions.TerminalInfo{
+			TerminalModel:  terminal.Model,
+			TerminalSerial: terminal.Serial,
+			Status:         definitions.TerminalStatusBinded,
+			Name:           terminal.Name,
+		}
+	}
+	s.mu.Unlock()
+
+	if len(terminals) == 0 {
+		writeError(w, http.StatusNotFound, "NOT_FOUND", "Available terminals not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, definitions.GetBindedTerminalsForIntegrationsAPIResponse{
+		Payload: definitions.GetBindedTerminalsPayload{AvailableTerminals: &terminals},
+		Errors:  []definitions.ErrorField{},
+	})
+}
+
+// createPaymentForIntegrationsAPI implements POST /payments/app-checkout.
+func (s *Server) createPaymentForIntegrationsAPI(w http.ResponseWriter, r *http.Request) {
+	var req definitions.CreatePaymentForIntegrationsAPIRequest
+	if !decodeRequest(w, r, validateIntegrationPaymentRequest, &req) {
+		return
+	}
+
+	s.mu.Lock()
+	var terminal *Terminal
+	for i := range s.terminals {
+		if s.terminals[i].Serial == req.TerminalSerial && s.terminals[i].Model == req.TerminalModel {
+			terminal = &s.terminals[i]
+			break
+		}
+	}
+	var onPayment func(definitions.CreatePaymentForIntegrationsAPIRequest) error
+	if terminal != nil {
+		onPayment = terminal.OnPayment
+	}
+	unavailable := terminal == nil || terminal.Unavailable
+	s.mu.Unlock()
+
+	if unavailable {
+		writeError(w, http.StatusBadRequest, "TERMINAL_NOT_AVAILABLE", "Terminal no disponible")
+		return
+	}
+	if onPayment != nil {
+		if err := onPayment(req); err != nil {
+			writeError(w, http.StatusBadRequest, "PAYMENT_REJECTED", err.Error())
+			return
+		}
+	}
+
+	s.mu.Lock()
+	s.sequence++
+	id := fmt.Sprintf("INT_%06d", s.sequence)
+	s.payments = append(s.payments, Payment{IntegrationID: id, Request: req})
+	s.mu.Unlock()
+
+	writeJSON(w, http.StatusCreated, definitions.CreatePaymentForIntegrationsAPIResponse{
+		Payload: definitions.IntegrationPaymentData{IntegrationID: id},
+		Errors:  []definitions.ErrorField{},
+	})
+}
+
+// SetPaymentLinkStatus changes the status of a payment link, simulating the
+// actions of the payer. Paid links get a transaction ID.
+func (s *Server) SetPaymentLinkStatus(id string, status definitions.PaymentLinkStatus) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	details, ok := s.links[id]
+	if !ok {
+		return fmt.Errorf("payment link %s not found", id)
+	}
+
+	details.Status = status
+	if status == definitions.PaymentLinkStatusPaid && details.TransactionID == nil {
+		transactionID := "TX_" + strings.TrimPrefix(id, "LNK_")
+		details.TransactionID = &transactionID
+	}
+	return nil
+}
+
+// PaymentLink returns the current data of a payment link.
+func (s *Server) PaymentLink(id string) (definitions.PaymentLinkDetails, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	details, ok := s.links[id]
+	if !ok {
+		return definitions.PaymentLinkDetails{}, false
+	}
+	expireLink(details)
+	return *details, true
+}
+
+// SetTerminals replaces the terminals binded to the integration.
+func (s *Server) SetTerminals(terminals ...Terminal) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.terminals = terminals
+}
+
+// Payments returns the payments created through the integrations API, in order.
+func (s *Server) Payments() []Payment {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Payment(nil), s.payments...)
+}
+
+// Requests returns the requests received by the server, in order.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+// expireLink moves an active payment link to EXPIRED once its expiration date passes.
+func expireLink(details *definitions.PaymentLinkDetails) {
+	if details.Status == definitions.PaymentLinkStatusActive && details.ExpirationDate != nil &&
+		time.Now().UnixNano() > *details.ExpirationDate {
+		details.Status = definitions.PaymentLinkStatusExpired
+	}
+}
+
+// writeJSON writes a JSON response.
+func writeJSON(w http.ResponseWriter, statusCode int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+// writeError writes an error response with the format used by Bold.
+func writeError(w http.ResponseWriter, statusCode int, code string, message string) {
+	writeJSON(w, statusCode, map[string]any{
+		"payload": map[string]any{},
+		"errors":  []definitions.ErrorField{{"code": code, "message": message}},
+	})
+}
+
+// decodeRequest validates the body of the request and decodes it into req,
+// writing the field errors if it is invalid. It reports whether it is valid.
+func decodeRequest(w http.ResponseWriter, r *http.Request, validate func(body []byte) []definitions.ErrorField, req any) bool {
+	body, err := io.ReadAll(r.Body)
+	if err == nil {
+		if fields := validate(body); len(fields) > 0 {
+			writeJSON(w, http.StatusBadRequest, map[string]any{
+				"payload": map[string]any{},
+				"errors":  fields,
+			})
+			return false
+		}
+		err = json.Unmarshal(body, req)
+	}
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_BODY", err.Error())
+		return false
+	}
+	return true
+}
@@ -0,0 +1,281 @@
+package boldtest_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/PChaparro/bold-co-sdk/src/boldtest"
+	"github.com/PChaparro/bold-co-sdk/src/definitions"
+	"github.com/PChaparro/bold-co-sdk/src/internal/tests"
+	"github.com/PChaparro/bold-co-sdk/src/sdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServer(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("payment links", func(t *testing.T) {
+		server := boldtest.NewServer(boldtest.Config{})
+		defer server.Close()
+		client := sdk.NewClient(sdk.ClientConfig{ApiKey: boldtest.DefaultAPIKey, BaseURL: server.URL})
+
+		req := tests.GetPayloadToCreateValidPaymentLink()
+		created, err := client.CreatePaymentLink(ctx, *req)
+		require.NoError(t, err)
+		assert.NotEmpty(t, created.Payload.URL)
+
+		details, err := client.GetPaymentLinkData(ctx, created.Payload.PaymentLink)
+		require.NoError(t, err)
+		assert.Equal(t, definitions.PaymentLinkStatusActive, details.Status)
+		assert.Equal(t, req.Amount.TotalAmount, details.Total)
+		assert.Equal(t, 8403.0, details.Subtotal)
+		assert.Equal(t, req.Description, *details.Description)
+		assert.Equal(t, req.ExpirationDate, *details.ExpirationDate)
+
+		require.NoError(t, server.SetPaymentLinkStatus(created.Payload.PaymentLink, definitions.PaymentLinkStatusPaid))
+		details, err = client.GetPaymentLinkData(ctx, created.Payload.PaymentLink)
+		require.NoError(t, err)
+		assert.Equal(t, definitions.PaymentLinkStatusPaid, details.Status)
+		assert.NotNil(t, details.TransactionID)
+
+		_, err = client.GetPaymentLinkData(ctx, "LNK_MISSING")
+		assert.ErrorIs(t, err, sdk.ErrNotFound)
+
+		methods, err := client.GetPaymentMethodsForPaymentLink(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, boldtest.DefaultPaymentMethods, methods.Payload.PaymentMethods)
+	})
+
+	t.Run("payment links expire", func(t *testing.T) {
+		server := boldtest.NewServer(boldtest.Config{})
+		defer server.Close()
+		client := sdk.NewClient(sdk.ClientConfig{ApiKey: boldtest.DefaultAPIKey, BaseURL: server.URL})
+
+		req := tests.GetPayloadToCreateValidPaymentLink()
+		req.ExpirationDate = time.Now().Add(20 * time.Millisecond).UnixNano()
+		created, err := client.CreatePaymentLink(ctx, *req)
+		require.NoError(t, err)
+
+		time.Sleep(30 * time.Millisecond)
+		details, ok := server.PaymentLink(created.Payload.PaymentLink)
+		require.True(t, ok)
+		assert.Equal(t, definitions.PaymentLinkStatusExpired, details.Status)
+	})
+
+	t.Run("invalid requests are rejected", func(t *testing.T) {
+		server := boldtest.NewServer(boldtest.Config{})
+		defer server.Close()
+		client := sdk.NewClient(sdk.ClientConfig{ApiKey: boldtest.DefaultAPIKey, BaseURL: server.URL, DisableValidation: true})
+
+		_, err := client.CreatePaymentLink(ctx, definitions.CreatePaymentLinkRequest{AmountType: definitions.AmountTypeClose})
+		require.ErrorIs(t, err, sdk.ErrValidation)
+
+		var apiErr *sdk.APIError
+		require.ErrorAs(t, err, &apiErr)
+		require.NotEmpty(t, apiErr.Errors)
+		assert.Equal(t, "amount", apiErr.Errors[0]["field"])
+	})
+
+	t.Run("the API key is checked", func(t *testing.T) {
+		server := boldtest.NewServer(boldtest.Config{APIKey: "secret"})
+		defer server.Close()
+		client := sdk.NewClient(sdk.ClientConfig{ApiKey: "wrong", BaseURL: server.URL})
+
+		_, err := client.GetPaymentMethodsForPaymentLink(ctx)
+		assert.ErrorIs(t, err, sdk.ErrUnauthorized)
+	})
+
+	t.Run("integrations API with scripted terminals", func(t *testing.T) {
+		server := boldtest.NewServer(boldtest.Config{})
+		defer server.Close()
+		client := sdk.NewClient(sdk.ClientConfig{ApiKey: boldtest.DefaultAPIKey, BaseURL: server.URL})
+
+		_, err := client.GetBindedTerminalsForIntegrationsAPI(ctx)
+		require.ErrorIs(t, err, sdk.ErrNotFound)
+
+		server.SetTerminals(
+			boldtest.Terminal{Model: "N86", Serial: "N860W000000", Name: "Front desk"},
+			boldtest.Terminal{Model: "N86", Serial: "N860W000001", Name: "Offline", Unavailable: true},
+			boldtest.Terminal{Model: "N86", Serial: "N860W000002", Name: "Declines", OnPayment: func(req definitions.CreatePaymentForIntegrationsAPIRequest) error {
+				return errors.New("Fondos insuficientes")
+			}},
+		)
+
+		terminals, err := client.GetBindedTerminalsForIntegrationsAPI(ctx)
+		require.NoError(t, err)
+		require.Len(t, *terminals.Payload.AvailableTerminals, 3)
+		assert.Equal(t, definitions.TerminalStatusBinded, (*terminals.Payload.AvailableTerminals)[0].Status)
+
+		req := tests.GetPayloadToCreateValidPaymentForIntegrationsAPI()
+		payment, err := client.CreatePaymentForIntegrationsAPI(ctx, *req)
+		require.NoError(t, err)
+		assert.NotEmpty(t, payment.Payload.IntegrationID)
+		require.Len(t, server.Payments(), 1)
+		assert.Equal(t, req.Reference, server.Payments()[0].Request.Reference)
+
+		req.TerminalSerial = "N860W000001"
+		_, err = client.CreatePaymentForIntegrationsAPI(ctx, *req)
+		assert.ErrorContains(t, err, "Terminal no disponible")
+
+		req.TerminalSerial = "N860W000002"
+		_, err = client.CreatePaymentForIntegrationsAPI(ctx, *req)
+		assert.ErrorContains(t, err, "Fondos insuficientes")
+
+		methods, err := client.GetPaymentMethodsForIntegrationsAPI(ctx)
+		require.NoError(t, err)
+		assert.Len(t, *methods.Payload.PaymentMethods, len(boldtest.DefaultIntegrationPaymentMethods))
+	})
+
+	t.Run("fault injection", func(t *testing.T) {
+		server := boldtest.NewServer(boldtest.Config{})
+		defer server.Close()
+		client := sdk.NewClient(sdk.ClientConfig{
+			ApiKey:      boldtest.DefaultAPIKey,
+			BaseURL:     server.URL,
+			RetryPolicy: &sdk.RetryPolicy{MaxAttempts: 3, RetryableStatusCodes: []int{http.StatusServiceUnavailable}},
+		})
+
+		server.InjectFault(boldtest.Fault{Path: "/online/link/v1/payment_methods", StatusCode: http.StatusServiceUnavailable, Times: 2})
+		_, err := client.GetPaymentMethodsForPaymentLink(ctx)
+		require.NoError(t, err, "the client retries until the fault is exhausted")
+		assert.Len(t, server.Requests(), 3)
+
+		server.InjectFault(boldtest.Fault{Method: http.MethodPost, StatusCode: http.StatusTooManyRequests, Headers: map[string]string{"Retry-After": "0"}})
+		_, err = client.CreatePaymentLink(ctx, *tests.GetPayloadToCreateValidPaymentLink())
+		require.ErrorIs(t, err, sdk.ErrRateLimited)
+		server.ClearFaults()
+
+		server.InjectFault(boldtest.Fault{DropConnection: true, Times: 1})
+		_, err = client.GetPaymentMethodsForIntegrationsAPI(ctx, sdk.WithTimeout(time.Second))
+		require.NoError(t, err, "GET requests are retried after a dropped connection")
+
+		server.InjectFault(boldtest.Fault{Delay: time.Second})
+		_, err = client.GetPaymentMethodsForIntegrationsAPI(ctx, sdk.WithTimeout(50*time.Millisecond))
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
+
+func TestServerValidation(t *testing.T) {
+	server := boldtest.NewServer(boldtest.Config{Terminals: []boldtest.Terminal{{Model: "N86", Serial: "N860W000000"}}})
+	defer server.Close()
+
+	// The requests are sent as raw JSON, since the server must not rely on the SDK to validate them
+	post := func(t *testing.T, path string, body string) (int, []definitions.ErrorField) {
+		req, err := http.NewRequest(http.MethodPost, server.URL+path, strings.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "x-api-key "+boldtest.DefaultAPIKey)
+
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer func() {
+			_ = resp.Body.Close()
+		}()
+
+		var response struct {
+			Errors []definitions.ErrorField `json:"errors"`
+		}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&response))
+		return resp.StatusCode, response.Errors
+	}
+
+	future := strconv.FormatInt(time.Now().Add(time.Hour).UnixNano(), 10)
+	amount := `{"currency":"COP","total_amount":10000,"tip_amount":0,"taxes":[{"type":"VAT","base":8403,"value":1597}]}`
+	payment := `"user_email":"seller@example.com","payment_method":"POS","terminal_model":"N86","terminal_serial":"N860W000000","reference":"ORDER-1"`
+
+	testCases := []struct {
+		name   string
+		path   string
+		body   string
+		fields []string
+	}{
+		{
+			name: "valid payment link",
+			path: "/online/link/v1",
+			body: `{"amount_type":"CLOSE","amount":` + amount + `,"expiration_date":` + future + `,"payment_methods":["PSE"]}`,
+		},
+		{
+			name: "valid open payment link",
+			path: "/online/link/v1",
+			body: `{"amount_type":"OPEN"}`,
+		},
+		{
+			name:   "not a JSON object",
+			path:   "/online/link/v1",
+			body:   `[]`,
+			fields: []string{""},
+		},
+		{
+			name:   "missing amount",
+			path:   "/online/link/v1",
+			body:   `{"amount_type":"CLOSE"}`,
+			fields: []string{"amount"},
+		},
+		{
+			name:   "amounts sent as strings",
+			path:   "/online/link/v1",
+			body:   `{"amount_type":"CLOSE","amount":{"currency":"COP","total_amount":"10000","taxes":[{"type":"VAT","base":"8403","value":1597}]}}`,
+			fields: []string{"amount.total_amount", "amount.taxes[0].base"},
+		},
+		{
+			name:   "expiration date in seconds",
+			path:   "/online/link/v1",
+			body:   `{"amount_type":"OPEN","expiration_date":` + strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10) + `}`,
+			fields: []string{"expiration_date"},
+		},
+		{
+			name:   "misspelled fields",
+			path:   "/online/link/v1",
+			body:   `{"amount_type":"OPEN","callbackUrl":"https://example.com","payment_method":["PSE"]}`,
+			fields: []string{"callbackUrl", "payment_method"},
+		},
+		{
+			name: "invalid values",
+			path: "/online/link/v1",
+			body: `{"amount_type":"CLOSED","description":"x","callback_url":"http://example.com","image_url":"https://example.com/image.gif",` +
+				`"payment_methods":["POS"],"payer_email":"not an email"}`,
+			fields: []string{"amount_type", "description", "callback_url", "image_url", "payment_methods[0]", "payer_email"},
+		},
+		{
+			name: "valid integrations API payment",
+			path: "/payments/app-checkout",
+			body: `{"amount":` + amount + `,` + payment + `,"payer":{"email":"payer@example.com","document":{"document_type":"CEDULA","document_number":"1234567890"}}}`,
+		},
+		{
+			name:   "missing integrations API fields",
+			path:   "/payments/app-checkout",
+			body:   `{"amount":{"currency":"COP","total_amount":0}}`,
+			fields: []string{"amount.total_amount", "user_email", "payment_method", "terminal_model", "terminal_serial", "reference"},
+		},
+		{
+			name:   "invalid integrations API values",
+			path:   "/payments/app-checkout",
+			body:   `{"amount":{"currency":"EUR","total_amount":1000,"tip_amount":-1},` + payment + `,"payer":{"document":{"document_type":"DNI"}}}`,
+			fields: []string{"amount.currency", "amount.tip_amount", "payer.document.document_type", "payer.document.document_number"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			statusCode, errs := post(t, tc.path, tc.body)
+
+			if len(tc.fields) == 0 {
+				assert.Less(t, statusCode, 300, "errors: %v", errs)
+				return
+			}
+
+			assert.Equal(t, http.StatusBadRequest, statusCode)
+			fields := make([]string, len(errs))
+			for i, fieldErr := range errs {
+				fields[i] = fieldErr["field"]
+			}
+			assert.ElementsMatch(t, tc.fields, fields)
+		})
+	}
+}
@@ -0,0 +1,345 @@
+package boldtest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/mail"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/PChaparro/bold-co-sdk/src/definitions"
+)
+
+// The values and fields accepted by the Bold API, as documented. They are
+// listed here instead of being taken from the SDK, so the server does not
+// share its mistakes.
+var (
+	amountTypes         = []string{"OPEN", "CLOSE"}
+	currencies          = []string{"COP", "USD"}
+	taxTypes            = []string{"VAT", "CONSUMPTION"}
+	paymentLinkMethods  = []string{"CREDIT_CARD", "PSE", "BOTON_BANCOLOMBIA", "NEQUI"}
+	integrationMethods  = []string{"", "POS", "NEQUI", "DAVIPLATA", "PAY_BY_LINK"}
+	imageURLExtensions  = []string{".png", ".jpg"}
+	paymentLinkFields   = []string{"amount_type", "amount", "description", "expiration_date", "callback_url", "payment_methods", "payer_email", "image_url"}
+	integrationFields   = []string{"amount", "user_email", "payment_method", "terminal_model", "terminal_serial", "reference", "description", "payer"}
+	amountFields        = []string{"currency", "taxes", "tip_amount", "total_amount"}
+	taxFields           = []string{"type", "base", "value"}
+	payerFields         = []string{"email", "phone_number", "document"}
+	payerDocumentFields = []string{"document_type", "document_number"}
+	documentTypes       = []string{
+		"CEDULA", "NIT", "CEDULA_EXTRANJERIA", "PEP", "PASAPORTE", "NUIP",
+		"REGISTRO_CIVIL", "DOCUMENTO_EXTRANJERIA", "TARJETA_IDENTIDAD", "PPT",
+	}
+)
+
+// Length limits of the description of the payment links.
+const (
+	minDescriptionLength = 2
+	maxDescriptionLength = 100
+)
+
+// requestValidator checks the raw JSON body of a request against the
+// constraints documented by Bold. It works on the JSON fields, independently
+// of the request types and the validation of the SDK, so it also detects the
+// requests that the SDK serializes or validates wrongly.
+type requestValidator struct {
+	errors []definitions.ErrorField
+}
+
+// validatePaymentLinkRequest checks the body of POST /online/link/v1.
+func validatePaymentLinkRequest(body []byte) []definitions.ErrorField {
+	var v requestValidator
+	request, ok := v.decode(body)
+	if !ok {
+		return v.errors
+	}
+
+	v.knownFields(request, "", paymentLinkFields)
+
+	amountType, _ := v.enum(request, "", "amount_type", true, amountTypes)
+	amount, ok := v.object(request, "", "amount", amountType == "CLOSE")
+	if ok {
+		v.amount(amount, "amount.")
+	}
+
+	if description, ok := v.str(request, "", "description", false); ok {
+		length := utf8.RuneCountInString(description)
+		v.check(length >= minDescriptionLength && length <= maxDescriptionLength,
+			"description", "must be between %d and %d characters", minDescriptionLength, maxDescriptionLength)
+	}
+
+	if expirationDate, ok := v.integer(request, "", "expiration_date", false); ok {
+		v.check(expirationDate > time.Now().UnixNano(), "expiration_date", "must be a future date in Unix nanoseconds")
+	}
+
+	if callbackURL, ok := v.str(request, "", "callback_url", false); ok {
+		v.check(isHTTPS(callbackURL), "callback_url", "must be an https:// URL")
+	}
+
+	if imageURL, ok := v.str(request, "", "image_url", false); ok {
+		v.check(isHTTPS(imageURL) && hasExtension(imageURL, imageURLExtensions),
+			"image_url", "must be an https:// URL of a %s image", strings.Join(imageURLExtensions, " or "))
+	}
+
+	if methods, ok := v.array(request, "", "payment_methods", false); ok {
+		for i := range methods {
+			v.enumItem(methods, "payment_methods", i, paymentLinkMethods)
+		}
+	}
+
+	if email, ok := v.str(request, "", "payer_email", false); ok {
+		v.check(isEmail(email), "payer_email", "must be a valid email")
+	}
+
+	return v.errors
+}
+
+// validateIntegrationPaymentRequest checks the body of POST /payments/app-checkout.
+func validateIntegrationPaymentRequest(body []byte) []definitions.ErrorField {
+	var v requestValidator
+	request, ok := v.decode(body)
+	if !ok {
+		return v.errors
+	}
+
+	v.knownFields(request, "", integrationFields)
+
+	if amount, ok := v.object(request, "", "amount", true); ok {
+		v.amount(amount, "amount.")
+	}
+
+	if email, ok := v.str(request, "", "user_email", true); ok {
+		v.check(isEmail(email), "user_email", "must be a valid email")
+	}
+
+	v.enum(request, "", "payment_method", true, integrationMethods)
+	for _, field := range []string{"terminal_model", "terminal_serial", "reference"} {
+		if value, ok := v.str(request, "", field, true); ok {
+			v.check(strings.TrimSpace(value) != "", field, "must not be empty")
+		}
+	}
+	v.str(request, "", "description", false)
+
+	if payer, ok := v.object(request, "", "payer", false); ok {
+		v.knownFields(payer, "payer.", payerFields)
+
+		if email, ok := v.str(payer, "payer.", "email", false); ok {
+			v.check(isEmail(email), "payer.email", "must be a valid email")
+		}
+		v.str(payer, "payer.", "phone_number", false)
+
+		if document, ok := v.object(payer, "payer.", "document", false); ok {
+			v.knownFields(document, "payer.document.", payerDocumentFields)
+			v.enum(document, "payer.document.", "document_type", true, documentTypes)
+			v.str(document, "payer.document.", "document_number", true)
+		}
+	}
+
+	return v.errors
+}
+
+// amount checks an amount object, shared by both APIs.
+func (v *requestValidator) amount(amount map[string]any, prefix string) {
+	v.knownFields(amount, prefix, amountFields)
+	v.enum(amount, prefix, "currency", true, currencies)
+
+	if total, ok := v.number(amount, prefix, "total_amount", true); ok {
+		v.check(total > 0, prefix+"total_amount", "must be greater than 0")
+	}
+	if tip, ok := v.number(amount, prefix, "tip_amount", false); ok {
+		v.check(tip >= 0, prefix+"tip_amount", "must not be negative")
+	}
+
+	taxes, ok := v.array(amount, prefix, "taxes", false)
+	if !ok {
+		return
+	}
+	for i, item := range taxes {
+		field := fmt.Sprintf("%staxes[%d]", prefix, i)
+		tax, ok := item.(map[string]any)
+		if !ok {
+			v.add(field, "must be an object")
+			continue
+		}
+
+		v.knownFields(tax, field+".", taxFields)
+		v.enum(tax, field+".", "type", true, taxTypes)
+		for _, key := range []string{"base", "value"} {
+			if value, ok := v.number(tax, field+".", key, true); ok {
+				v.check(value >= 0, field+"."+key, "must not be negative")
+			}
+		}
+	}
+}
+
+// decode parses the body as a JSON object, keeping the numbers as written.
+func (v *requestValidator) decode(body []byte) (map[string]any, bool) {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+
+	var request map[string]any
+	if err := decoder.Decode(&request); err != nil || request == nil {
+		v.errors = append(v.errors, definitions.ErrorField{"code": "INVALID_BODY", "message": "the body must be a JSON object"})
+		return nil, false
+	}
+	return request, true
+}
+
+// add registers an error for the given field.
+func (v *requestValidator) add(field string, format string, args ...any) {
+	v.errors = append(v.errors, definitions.ErrorField{
+		"code":    "VALIDATION_ERROR",
+		"field":   field,
+		"message": fmt.Sprintf(format, args...),
+	})
+}
+
+// check registers an error for the given field if the condition is false.
+func (v *requestValidator) check(condition bool, field string, format string, args ...any) {
+	if !condition {
+		v.add(field, format, args...)
+	}
+}
+
+// knownFields reports the fields of the object that are not documented.
+func (v *requestValidator) knownFields(object map[string]any, prefix string, known []string) {
+	var unknown []string
+	for key := range object {
+		if !slices.Contains(known, key) {
+			unknown = append(unknown, key)
+		}
+	}
+	slices.Sort(unknown)
+	for _, key := range unknown {
+		v.add(prefix+key, "is not a known field")
+	}
+}
+
+// field returns the value of a field, reporting it if it is required and
+// missing. Null values are treated as missing.
+func (v *requestValidator) field(object map[string]any, prefix, key string, required bool) (any, bool) {
+	value, ok := object[key]
+	if !ok || value == nil {
+		if required {
+			v.add(prefix+key, "is required")
+		}
+		return nil, false
+	}
+	return value, true
+}
+
+// str returns the value of a string field.
+func (v *requestValidator) str(object map[string]any, prefix, key string, required bool) (string, bool) {
+	value, ok := v.field(object, prefix, key, required)
+	if !ok {
+		return "", false
+	}
+	str, ok := value.(string)
+	if !ok {
+		v.add(prefix+key, "must be a string")
+	}
+	return str, ok
+}
+
+// number returns the value of a number field.
+func (v *requestValidator) number(object map[string]any, prefix, key string, required bool) (float64, bool) {
+	value, ok := v.field(object, prefix, key, required)
+	if !ok {
+		return 0, false
+	}
+	if number, ok := value.(json.Number); ok {
+		if parsed, err := number.Float64(); err == nil {
+			return parsed, true
+		}
+	}
+	v.add(prefix+key, "must be a number")
+	return 0, false
+}
+
+// integer returns the value of an integer field.
+func (v *requestValidator) integer(object map[string]any, prefix, key string, required bool) (int64, bool) {
+	value, ok := v.field(object, prefix, key, required)
+	if !ok {
+		return 0, false
+	}
+	if number, ok := value.(json.Number); ok {
+		if parsed, err := number.Int64(); err == nil {
+			return parsed, true
+		}
+	}
+	v.add(prefix+key, "must be an integer")
+	return 0, false
+}
+
+// object returns the value of an object field.
+func (v *requestValidator) object(object map[string]any, prefix, key string, required bool) (map[string]any, bool) {
+	value, ok := v.field(object, prefix, key, required)
+	if !ok {
+		return nil, false
+	}
+	nested, ok := value.(map[string]any)
+	if !ok {
+		v.add(prefix+key, "must be an object")
+	}
+	return nested, ok
+}
+
+// array returns the value of an array field.
+func (v *requestValidator) array(object map[string]any, prefix, key string, required bool) ([]any, bool) {
+	value, ok := v.field(object, prefix, key, required)
+	if !ok {
+		return nil, false
+	}
+	items, ok := value.([]any)
+	if !ok {
+		v.add(prefix+key, "must be an array")
+	}
+	return items, ok
+}
+
+// enum returns the value of a string field that must be one of the allowed values.
+func (v *requestValidator) enum(object map[string]any, prefix, key string, required bool, allowed []string) (string, bool) {
+	value, ok := v.str(object, prefix, key, required)
+	if ok && !slices.Contains(allowed, value) {
+		v.add(prefix+key, "%q is not one of %s", value, strings.Join(allowed, ", "))
+		return value, false
+	}
+	return value, ok
+}
+
+// enumItem checks that an item of an array is one of the allowed values.
+func (v *requestValidator) enumItem(items []any, field string, i int, allowed []string) {
+	field = fmt.Sprintf("%s[%d]", field, i)
+	value, ok := items[i].(string)
+	if !ok {
+		v.add(field, "must be a string")
+		return
+	}
+	v.check(slices.Contains(allowed, value), field, "%q is not one of %s", value, strings.Join(allowed, ", "))
+}
+
+// isHTTPS reports whether the value is an absolute https:// URL.
+func isHTTPS(value string) bool {
+	parsed, err := url.Parse(value)
+	return err == nil && parsed.Scheme == "https" && parsed.Host != ""
+}
+
+// hasExtension reports whether the path of the URL ends with one of the extensions.
+func hasExtension(value string, extensions []string) bool {
+	parsed, err := url.Parse(value)
+	if err != nil {
+		return false
+	}
+	path := strings.ToLower(parsed.Path)
+	return slices.ContainsFunc(extensions, func(extension string) bool { return strings.HasSuffix(path, extension) })
+}
+
+// isEmail reports whether the value is a bare email address.
+func isEmail(value string) bool {
+	address, err := mail.ParseAddress(value)
+	return err == nil && address.Address == value
+}
@@ -0,0 +1,74 @@
+// Package cassette provides an HTTP transport that records the interactions
+// with the Bold API to JSON files (cassettes) and replays them later, so
+// integration tests can run deterministically without network access.
+//
+// The recorder is used through the Transport of the sdk.ClientConfig:
+//
+//	recorder, err := cassette.New(cassette.Config{Path: "testdata/payment_links.json", Mode: cassette.ModeReplay, Strict: true})
+//	client := sdk.NewClient(sdk.ClientConfig{ApiKey: apiKey, Transport: recorder})
+//
+// The API keys and the personal data of the payers are redacted before the
+// interactions are written, so cassettes can be committed.
+package cassette
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+)
+
+// Cassette is the content of a cassette file.
+type Cassette struct {
+	// Interactions are the recorded interactions, in the order they were sent.
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction is a recorded request with its response.
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Request is a recorded HTTP request.
+type Request struct {
+	Method string      `json:"method"`
+	Path   string      `json:"path"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+// Response is a recorded HTTP response.
+type Response struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// Load reads a cassette file.
+func Load(path string) (*Cassette, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var cassette Cassette
+	if err := json.Unmarshal(data, &cassette); err != nil {
+		return nil, fmt.Errorf("failed to decode cassette %s: %w", path, err)
+	}
+	return &cassette, nil
+}
+
+// Save writes the cassette to a file, creating its directory if needed.
+func (c *Cassette) Save(path string) error {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode cassette: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
@@ -0,0 +1,257 @@
+package cassette
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/PChaparro/bold-co-sdk/src/internal/redact"
+)
+
+// ErrUnmatchedRequest is returned by the recorder in strict replay mode when
+// a request does not match any unused interaction of the cassette.
+var ErrUnmatchedRequest = errors.New("cassette: no recorded interaction matches the request")
+
+// Mode defines whether the recorder records or replays the interactions.
+type Mode int
+
+const (
+	ModeReplay Mode = iota // Serve the requests from the cassette (default).
+	ModeRecord             // Send the requests and record them, replacing the cassette.
+)
+
+// Config contains the options of a Recorder.
+type Config struct {
+	// Path is the path of the cassette file.
+	Path string
+
+	// Mode defines whether the interactions are recorded or replayed.
+	Mode Mode
+
+	// Strict makes the replay fail with ErrUnmatchedRequest when a request
+	// does not match any unused interaction, and New fail when the cassette
+	// does not exist. Otherwise, unmatched requests are sent through the
+	// Transport and appended to the cassette.
+	Strict bool
+
+	// Transport sends the requests that are recorded.
+	// If not provided, it defaults to http.DefaultTransport.
+	Transport http.RoundTripper
+
+	// RedactFields are JSON fields redacted in addition to DefaultRedactedFields.
+	RedactFields []string
+
+	// RedactHeaders are headers redacted in addition to DefaultRedactedHeaders.
+	RedactHeaders []string
+
+	// IgnoreFields are JSON fields of the request bodies that are not compared
+	// when matching the requests, like the ones depending on the current time
+	// (e.g., "expiration_date").
+	IgnoreFields []string
+}
+
+// Recorder is an http.RoundTripper that records and replays the interactions
+// stored in a cassette. Requests are matched on their method, path and
+// normalized body; every recorded interaction is replayed at most once, in
+// the order it was recorded.
+type Recorder struct {
+	config          Config
+	transport       http.RoundTripper
+	redactedFields  map[string]bool
+	redactedHeaders []string
+	ignoredFields   map[string]bool
+
+	mu       sync.Mutex
+	cassette *Cassette
+	used     []bool
+	changed  bool
+}
+
+// Verify that Recorder implements the http.RoundTripper interface.
+var _ http.RoundTripper = (*Recorder)(nil)
+
+// New creates a Recorder. In replay mode, it loads the cassette.
+func New(config Config) (*Recorder, error) {
+	if config.Path == "" {
+		return nil, errors.New("cassette: the path of the cassette is required")
+	}
+
+	recorder := &Recorder{
+		config:          config,
+		transport:       config.Transport,
+		redactedFields:  redact.Set(DefaultRedactedFields, config.RedactFields),
+		redactedHeaders: append(append([]string{}, DefaultRedactedHeaders...), config.RedactHeaders...),
+		ignoredFields:   redact.Set(config.IgnoreFields),
+		cassette:        &Cassette{},
+	}
+	if recorder.transport == nil {
+		recorder.transport = http.DefaultTransport
+	}
+
+	if config.Mode == ModeReplay {
+		cassette, err := Load(config.Path)
+		switch {
+		case err == nil:
+			recorder.cassette = cassette
+		case !errors.Is(err, os.ErrNotExist) || config.Strict:
+			return nil, err
+		}
+	}
+	recorder.used = make([]bool, len(recorder.cassette.Interactions))
+
+	return recorder, nil
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.config.Mode == ModeReplay {
+		if interaction, ok := r.match(req, body); ok {
+			return replay(req, interaction), nil
+		}
+		if r.config.Strict {
+			return nil, fmt.Errorf("%w: %s %s", ErrUnmatchedRequest, req.Method, req.URL.Path)
+		}
+	}
+
+	return r.record(req, body)
+}
+
+// Save writes the cassette when new interactions were recorded.
+// It must be called once every request has been sent, e.g., with t.Cleanup.
+func (r *Recorder) Save() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.changed {
+		return nil
+	}
+	if err := r.cassette.Save(r.config.Path); err != nil {
+		return err
+	}
+	r.changed = false
+	return nil
+}
+
+// Unused returns the recorded interactions that were not replayed.
+func (r *Recorder) Unused() []Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var unused []Interaction
+	for i, interaction := range r.cassette.Interactions {
+		if !r.used[i] {
+			unused = append(unused, interaction)
+		}
+	}
+	return unused
+}
+
+// match returns the first unused interaction matching the request, marking it as used.
+func (r *Recorder) match(req *http.Request, body []byte) (Interaction, bool) {
+	normalized := r.normalizeBody(string(body))
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, interaction := range r.cassette.Interactions {
+		recorded := interaction.Request
+		if r.used[i] || !strings.EqualFold(recorded.Method, req.Method) || recorded.Path != req.URL.Path {
+			continue
+		}
+		if r.normalizeBody(recorded.Body) != normalized {
+			continue
+		}
+
+		r.used[i] = true
+		return interaction, true
+	}
+
+	return Interaction{}, false
+}
+
+// record sends the request through the transport and appends the redacted
+// interaction to the cassette.
+func (r *Recorder) record(req *http.Request, body []byte) (*http.Response, error) {
+	res, err := r.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resBody, err := io.ReadAll(res.Body)
+	_ = res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	res.Body = io.NopCloser(bytes.NewReader(resBody))
+
+	// The length changes when the body is redacted
+	resHeader := redact.Header(res.Header, r.redactedHeaders)
+	resHeader.Del("Content-Length")
+
+	interaction := Interaction{
+		Request: Request{
+			Method: req.Method,
+			Path:   req.URL.Path,
+			Header: redact.Header(req.Header, r.redactedHeaders),
+			Body:   redact.JSON(body, r.redactedFields),
+		},
+		Response: Response{
+			StatusCode: res.StatusCode,
+			Header:     resHeader,
+			Body:       redact.JSON(resBody, r.redactedFields),
+		},
+	}
+
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, interaction)
+	r.used = append(r.used, true)
+	r.changed = true
+	r.mu.Unlock()
+
+	return res, nil
+}
+
+// replay builds the response of a recorded interaction.
+func replay(req *http.Request, interaction Interaction) *http.Response {
+	header := interaction.Response.Header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", interaction.Response.StatusCode, http.StatusText(interaction.Response.StatusCode)),
+		StatusCode:    interaction.Response.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(strings.NewReader(interaction.Response.Body)),
+		ContentLength: int64(len(interaction.Response.Body)),
+		Request:       req,
+	}
+}
+
+// readBody reads the body of a request, replacing it so it can be sent again.
+func readBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+
+	body, err := io.ReadAll(req.Body)
+	_ = req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
@@ -0,0 +1,107 @@
+package cassette_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/PChaparro/bold-co-sdk/src/boldtest"
+	"github.com/PChaparro/bold-co-sdk/src/cassette"
+	"github.com/PChaparro/bold-co-sdk/src/definitions"
+	"github.com/PChaparro/bold-co-sdk/src/internal/tests"
+	"github.com/PChaparro/bold-co-sdk/src/sdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecorder(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "cassettes", "payment_links.json")
+
+	// Record the interactions with the test server
+	server := boldtest.NewServer(boldtest.Config{})
+	recorder, err := cassette.New(cassette.Config{Path: path, Mode: cassette.ModeRecord, IgnoreFields: []string{"expiration_date"}})
+	require.NoError(t, err)
+	client := sdk.NewClient(sdk.ClientConfig{ApiKey: boldtest.DefaultAPIKey, BaseURL: server.URL, Transport: recorder})
+
+	req := tests.GetPayloadToCreateValidPaymentLink()
+	req.PayerEmail = "payer@example.com"
+	created, err := client.CreatePaymentLink(ctx, *req)
+	require.NoError(t, err)
+	id := created.Payload.PaymentLink
+
+	require.NoError(t, server.SetPaymentLinkStatus(id, definitions.PaymentLinkStatusPaid))
+	_, err = client.GetPaymentLinkData(ctx, id)
+	require.NoError(t, err)
+
+	require.NoError(t, recorder.Save())
+	server.Close()
+
+	t.Run("secrets and personal data are redacted", func(t *testing.T) {
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.NotContains(t, string(data), boldtest.DefaultAPIKey)
+		assert.NotContains(t, string(data), "payer@example.com")
+
+		recorded, err := cassette.Load(path)
+		require.NoError(t, err)
+		require.Len(t, recorded.Interactions, 2)
+		assert.Equal(t, cassette.Redacted, recorded.Interactions[0].Request.Header.Get("Authorization"))
+	})
+
+	t.Run("interactions are replayed without network", func(t *testing.T) {
+		recorder, err := cassette.New(cassette.Config{Path: path, Strict: true, IgnoreFields: []string{"expiration_date"}})
+		require.NoError(t, err)
+		client := sdk.NewClient(sdk.ClientConfig{ApiKey: "another-key", BaseURL: server.URL, Transport: recorder})
+
+		// The expiration date changes, but it is ignored
+		replayed, err := client.CreatePaymentLink(ctx, *tests.GetPayloadToCreateValidPaymentLink())
+		require.NoError(t, err)
+		assert.Equal(t, id, replayed.Payload.PaymentLink)
+
+		details, err := client.GetPaymentLinkData(ctx, id)
+		require.NoError(t, err)
+		assert.Equal(t, definitions.PaymentLinkStatusPaid, details.Status)
+		assert.Empty(t, recorder.Unused())
+
+		// Every interaction is replayed once
+		_, err = client.GetPaymentLinkData(ctx, id)
+		assert.ErrorIs(t, err, cassette.ErrUnmatchedRequest)
+	})
+
+	t.Run("bodies are matched", func(t *testing.T) {
+		recorder, err := cassette.New(cassette.Config{Path: path, Strict: true})
+		require.NoError(t, err)
+		client := sdk.NewClient(sdk.ClientConfig{ApiKey: boldtest.DefaultAPIKey, BaseURL: server.URL, Transport: recorder})
+
+		req := tests.GetPayloadToCreateValidPaymentLink()
+		req.Description = "Another description"
+		_, err = client.CreatePaymentLink(ctx, *req)
+		assert.ErrorIs(t, err, cassette.ErrUnmatchedRequest)
+		assert.Len(t, recorder.Unused(), 2)
+	})
+
+	t.Run("strict mode requires the cassette", func(t *testing.T) {
+		_, err := cassette.New(cassette.Config{Path: filepath.Join(t.TempDir(), "missing.json"), Strict: true})
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+
+	t.Run("unmatched requests are recorded when not strict", func(t *testing.T) {
+		server := boldtest.NewServer(boldtest.Config{})
+		defer server.Close()
+
+		path := filepath.Join(t.TempDir(), "new.json")
+		recorder, err := cassette.New(cassette.Config{Path: path})
+		require.NoError(t, err)
+		client := sdk.NewClient(sdk.ClientConfig{ApiKey: boldtest.DefaultAPIKey, BaseURL: server.URL, Transport: recorder})
+
+		_, err = client.GetPaymentMethodsForPaymentLink(ctx)
+		require.NoError(t, err)
+		require.NoError(t, recorder.Save())
+
+		recorded, err := cassette.Load(path)
+		require.NoError(t, err)
+		assert.Len(t, recorded.Interactions, 1)
+	})
+}
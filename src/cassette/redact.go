@@ -0,0 +1,36 @@
+package cassette
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+
+	"github.com/PChaparro/bold-co-sdk/src/internal/redact"
+)
+
+// Redacted replaces the redacted values in the cassettes.
+const Redacted = redact.Placeholder
+
+// DefaultRedactedFields are the JSON fields redacted from the request and
+// response bodies: the contact and identification data of payers and sellers.
+var DefaultRedactedFields = slices.Clone(redact.Fields)
+
+// DefaultRedactedHeaders are the headers redacted from the requests and
+// responses. The Authorization header contains the API key.
+var DefaultRedactedHeaders = slices.Clone(redact.Headers)
+
+// normalizeBody returns the form of a body used to match the requests: JSON
+// bodies are redacted, stripped of the ignored fields and encoded with sorted
+// keys, so the formatting and the order of the fields do not matter.
+func (r *Recorder) normalizeBody(body string) string {
+	value, ok := redact.DecodeJSON([]byte(body))
+	if !ok {
+		return strings.TrimSpace(body)
+	}
+
+	encoded, err := json.Marshal(redact.Value(value, r.redactedFields, r.ignoredFields))
+	if err != nil {
+		return strings.TrimSpace(body)
+	}
+	return string(encoded)
+}
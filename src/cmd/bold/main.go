@@ -0,0 +1,189 @@
+// Command bold provides development utilities for applications that integrate
+// with Bold using this SDK.
+//
+// Usage:
+//
+//	bold simulate-webhook [flags]
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	neturl "net/url"
+	"os"
+	"os/signal"
+	"strings"
+
+	"github.com/PChaparro/bold-co-sdk/src/definitions"
+	"github.com/PChaparro/bold-co-sdk/src/webhook/simulator"
+)
+
+// Exit codes of the command.
+const (
+	exitOK    = 0 // The command succeeded.
+	exitError = 1 // The command failed (e.g., the receiver is not reachable).
+	exitUsage = 2 // The command was invoked with invalid arguments.
+)
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	code := run(ctx, os.Args[1:], os.Stdout, os.Stderr)
+	stop()
+	os.Exit(code)
+}
+
+// run executes the subcommand given in the arguments and returns the exit code.
+func run(ctx context.Context, args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		printUsage(stderr)
+		return exitUsage
+	}
+
+	switch args[0] {
+	case "simulate-webhook":
+		return simulateWebhook(ctx, args[1:], stdout, stderr)
+	case "help", "-h", "-help", "--help":
+		printUsage(stdout)
+		return exitOK
+	default:
+		_, _ = fmt.Fprintf(stderr, "unknown command: %s\n\n", args[0])
+		printUsage(stderr)
+		return exitUsage
+	}
+}
+
+// printUsage writes the usage of the command.
+func printUsage(w io.Writer) {
+	_, _ = fmt.Fprint(w, "usage: bold <command> [flags]\n\ncommands:\n  simulate-webhook  send signed webhook notifications to a local receiver\n")
+}
+
+// simulateWebhook sends signed webhook notifications to a local receiver.
+func simulateWebhook(ctx context.Context, args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("simulate-webhook", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		_, _ = fmt.Fprint(stderr, "usage: bold simulate-webhook [flags]\n\nflags:\n")
+		flags.PrintDefaults()
+	}
+
+	url := flags.String("url", "http://localhost:8080/webhooks/bold", "URL of the webhook receiver")
+	secretKey := flags.String("secret", os.Getenv("BOLD_SECRET_KEY"), "secret key used to sign the notifications (defaults to $BOLD_SECRET_KEY)")
+	eventType := flags.String("type", string(definitions.WebhookEventTypeSaleApproved), "comma separated list of event types to send, in order")
+	paymentLinkID := flags.String("payment-link", "", "payment link ID the notifications refer to")
+	reference := flags.String("reference", "", "integrations API reference the notifications refer to")
+	total := flags.Float64("total", 10000, "total amount of the payment")
+	duplicates := flags.Int("duplicates", 0, "number of extra times each notification is delivered")
+	outOfOrder := flags.Bool("out-of-order", false, "deliver the notifications in reverse order")
+	badSignature := flags.Bool("bad-signature", false, "sign the notifications with a wrong secret key")
+
+	if err := flags.Parse(args); err != nil {
+		// The flag set already reported the error and the usage
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK
+		}
+		return exitUsage
+	}
+
+	eventTypes, err := parseEventTypes(*eventType)
+	switch {
+	case err != nil:
+		return usageError(flags, err)
+	case flags.NArg() > 0:
+		return usageError(flags, fmt.Errorf("unexpected arguments: %s", strings.Join(flags.Args(), " ")))
+	case *paymentLinkID == "" && *reference == "":
+		return usageError(flags, errors.New("either -payment-link or -reference must be provided"))
+	case *paymentLinkID != "" && *reference != "":
+		return usageError(flags, errors.New("only one of -payment-link or -reference can be provided"))
+	case !isHTTPURL(*url):
+		return usageError(flags, fmt.Errorf("invalid -url: %q is not an HTTP URL", *url))
+	case *total <= 0:
+		return usageError(flags, errors.New("-total must be greater than 0"))
+	case *duplicates < 0:
+		return usageError(flags, errors.New("-duplicates cannot be negative"))
+	}
+
+	sim := simulator.New(simulator.Config{
+		URL:       *url,
+		SecretKey: *secretKey,
+	})
+
+	// All the notifications refer to the same payment
+	var notifications []*definitions.WebhookNotification
+	var paymentID string
+	for _, eventType := range eventTypes {
+		notification := sim.NewNotification(simulator.NotificationOptions{
+			Type:          eventType,
+			PaymentLinkID: *paymentLinkID,
+			Reference:     *reference,
+			PaymentID:     paymentID,
+			Total:         *total,
+		})
+		paymentID = notification.Data.PaymentID
+		notifications = append(notifications, notification)
+	}
+
+	results, err := sim.Deliver(ctx, notifications, simulator.DeliveryOptions{
+		Duplicates:   *duplicates,
+		OutOfOrder:   *outOfOrder,
+		BadSignature: *badSignature,
+	})
+
+	for _, result := range results {
+		duplicate := ""
+		if result.Duplicate {
+			duplicate = " (duplicate)"
+		}
+		_, _ = fmt.Fprintf(stdout, "%s %s%s -> %d\n", result.Type, result.NotificationID, duplicate, result.StatusCode)
+	}
+
+	if err != nil {
+		_, _ = fmt.Fprintln(stderr, err)
+		return exitError
+	}
+	return exitOK
+}
+
+// usageError reports an invalid invocation of a command, followed by its usage.
+func usageError(flags *flag.FlagSet, err error) int {
+	_, _ = fmt.Fprintf(flags.Output(), "%s\n\n", err)
+	flags.Usage()
+	return exitUsage
+}
+
+// parseEventTypes parses a comma separated list of known event types.
+func parseEventTypes(value string) ([]definitions.WebhookEventType, error) {
+	items := splitList(value)
+	if len(items) == 0 {
+		return nil, errors.New("-type must contain at least one event type")
+	}
+
+	eventTypes := make([]definitions.WebhookEventType, 0, len(items))
+	for _, item := range items {
+		eventType := definitions.WebhookEventType(strings.ToUpper(item))
+		if !eventType.IsKnown() {
+			return nil, fmt.Errorf("unknown event type in -type: %s", item)
+		}
+		eventTypes = append(eventTypes, eventType)
+	}
+	return eventTypes, nil
+}
+
+// isHTTPURL reports whether the value is an absolute HTTP or HTTPS URL.
+func isHTTPURL(value string) bool {
+	parsed, err := neturl.Parse(value)
+	return err == nil && (parsed.Scheme == "http" || parsed.Scheme == "https") && parsed.Host != ""
+}
+
+// splitList splits a comma separated list, ignoring empty items.
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,174 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/PChaparro/bold-co-sdk/src/definitions"
+	"github.com/PChaparro/bold-co-sdk/src/webhook"
+	"github.com/stretchr/testify/assert"
+)
+
+const testSecretKey = "test-secret-key"
+
+func TestRun(t *testing.T) {
+	var mu sync.Mutex
+	var received []definitions.WebhookEventType
+
+	server := httptest.NewServer(webhook.NewHandler(webhook.HandlerConfig{
+		SecretKey: testSecretKey,
+		OnEvent: func(ctx context.Context, notification *definitions.WebhookNotification) error {
+			mu.Lock()
+			defer mu.Unlock()
+			received = append(received, notification.Type)
+			return nil
+		},
+	}))
+	defer server.Close()
+
+	closed := httptest.NewServer(http.NotFoundHandler())
+	closed.Close()
+
+	simulate := func(flags ...string) []string {
+		return append([]string{"simulate-webhook", "-url", server.URL, "-secret", testSecretKey}, flags...)
+	}
+
+	testCases := []struct {
+		name         string
+		args         []string
+		expectedCode int
+		expected     []definitions.WebhookEventType
+		stdout       string
+		stderr       string
+	}{
+		{
+			name:         "default event",
+			args:         simulate("-payment-link", "LNK_H7S4XKJ9WQ"),
+			expectedCode: exitOK,
+			expected:     []definitions.WebhookEventType{definitions.WebhookEventTypeSaleApproved},
+			stdout:       "SALE_APPROVED",
+		},
+		{
+			name:         "several events with duplicates",
+			args:         simulate("-reference", "ORDER-123", "-type", "SALE_APPROVED, void_approved", "-duplicates", "1"),
+			expectedCode: exitOK,
+			expected: []definitions.WebhookEventType{
+				definitions.WebhookEventTypeSaleApproved,
+				definitions.WebhookEventTypeSaleApproved,
+				definitions.WebhookEventTypeVoidApproved,
+				definitions.WebhookEventTypeVoidApproved,
+			},
+			stdout: "(duplicate)",
+		},
+		{
+			name:         "bad signature",
+			args:         simulate("-payment-link", "LNK_H7S4XKJ9WQ", "-bad-signature"),
+			expectedCode: exitOK,
+			stdout:       "-> 401",
+		},
+		{
+			name:         "no command",
+			args:         nil,
+			expectedCode: exitUsage,
+			stderr:       "usage: bold <command>",
+		},
+		{
+			name:         "unknown command",
+			args:         []string{"simulate"},
+			expectedCode: exitUsage,
+			stderr:       "unknown command: simulate",
+		},
+		{
+			name:         "help",
+			args:         []string{"simulate-webhook", "-h"},
+			expectedCode: exitOK,
+			stderr:       "usage: bold simulate-webhook",
+		},
+		{
+			name:         "unknown flag",
+			args:         simulate("-payment-link", "LNK_H7S4XKJ9WQ", "-amount", "10"),
+			expectedCode: exitUsage,
+			stderr:       "flag provided but not defined: -amount",
+		},
+		{
+			name:         "empty type",
+			args:         simulate("-payment-link", "LNK_H7S4XKJ9WQ", "-type", " , "),
+			expectedCode: exitUsage,
+			stderr:       "-type must contain at least one event type",
+		},
+		{
+			name:         "unknown type",
+			args:         simulate("-payment-link", "LNK_H7S4XKJ9WQ", "-type", "SALE_APPROVED,REFUND_APPROVED"),
+			expectedCode: exitUsage,
+			stderr:       "unknown event type in -type: REFUND_APPROVED",
+		},
+		{
+			name:         "missing payment",
+			args:         simulate(),
+			expectedCode: exitUsage,
+			stderr:       "either -payment-link or -reference must be provided",
+		},
+		{
+			name:         "payment link and reference",
+			args:         simulate("-payment-link", "LNK_H7S4XKJ9WQ", "-reference", "ORDER-123"),
+			expectedCode: exitUsage,
+			stderr:       "only one of -payment-link or -reference can be provided",
+		},
+		{
+			name:         "invalid URL",
+			args:         []string{"simulate-webhook", "-url", "localhost:8080", "-payment-link", "LNK_H7S4XKJ9WQ"},
+			expectedCode: exitUsage,
+			stderr:       "invalid -url",
+		},
+		{
+			name:         "invalid total",
+			args:         simulate("-payment-link", "LNK_H7S4XKJ9WQ", "-total", "0"),
+			expectedCode: exitUsage,
+			stderr:       "-total must be greater than 0",
+		},
+		{
+			name:         "negative duplicates",
+			args:         simulate("-payment-link", "LNK_H7S4XKJ9WQ", "-duplicates", "-1"),
+			expectedCode: exitUsage,
+			stderr:       "-duplicates cannot be negative",
+		},
+		{
+			name:         "unexpected arguments",
+			args:         simulate("-payment-link", "LNK_H7S4XKJ9WQ", "extra"),
+			expectedCode: exitUsage,
+			stderr:       "unexpected arguments: extra",
+		},
+		{
+			name:         "unreachable receiver",
+			args:         []string{"simulate-webhook", "-url", closed.URL, "-payment-link", "LNK_H7S4XKJ9WQ"},
+			expectedCode: exitError,
+			stderr:       "error delivering notification",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mu.Lock()
+			received = nil
+			mu.Unlock()
+
+			var stdout, stderr bytes.Buffer
+			code := run(context.Background(), tc.args, &stdout, &stderr)
+
+			assert.Equal(t, tc.expectedCode, code, "stderr: %s", stderr.String())
+			assert.Contains(t, stdout.String(), tc.stdout)
+			assert.Contains(t, stderr.String(), tc.stderr)
+			if tc.expectedCode == exitUsage {
+				assert.Contains(t, stderr.String(), "usage: bold")
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			assert.Equal(t, tc.expected, received)
+		})
+	}
+}
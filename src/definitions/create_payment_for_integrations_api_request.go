@@ -1,5 +1,10 @@
 package definitions
 
+import (
+	"slices"
+	"strings"
+)
+
 // IntegrationAmount represents the payment amount details for the integration API
 type IntegrationAmount struct {
 	Currency    CurrencyType `json:"currency"`        // Currency for the transaction (e.g., COP)
@@ -8,6 +13,21 @@ type IntegrationAmount struct {
 	TotalAmount float64      `json:"total_amount"`    // Total transaction amount including taxes and tips
 }
 
+// TotalMoney returns the total amount as an exact Money amount.
+func (a IntegrationAmount) TotalMoney() Money {
+	return MoneyFromFloat(a.TotalAmount, a.Currency)
+}
+
+// TipMoney returns the tip amount as an exact Money amount.
+func (a IntegrationAmount) TipMoney() Money {
+	return MoneyFromFloat(a.TipAmount, a.Currency)
+}
+
+// TaxesMoney returns the sum of the values of the taxes as an exact Money amount.
+func (a IntegrationAmount) TaxesMoney() Money {
+	return sumTaxValues(a.Taxes, a.Currency)
+}
+
 // PayerDocument represents the identification document of the payer for integration API
 type IntegrationPayerDocument struct {
 	DocumentType   DocumentType `json:"document_type"`   // Type of ID document (e.g., CEDULA, NIT, etc.)
@@ -32,3 +52,52 @@ type CreatePaymentForIntegrationsAPIRequest struct {
 	Description    string            `json:"description,omitempty"` // Optional: Brief description of the transaction
 	Payer          *IntegrationPayer `json:"payer,omitempty"`       // Optional: Object specifying the payer's details if needed
 }
+
+// integrationMethods are the payment methods available for the integrations API.
+// An empty payment method lets the payer choose it on the terminal.
+var integrationMethods = []PaymentMethod{
+	"",
+	PaymentMethodPos,
+	PaymentMethodNequi,
+	PaymentMethodDaviplata,
+	PaymentMethodPayByLink,
+}
+
+// Validate checks the request against the rules of the Bold API, so mistakes
+// are detected before sending it. It returns a *ValidationError listing every
+// invalid field, or nil if the request is valid.
+func (r CreatePaymentForIntegrationsAPIRequest) Validate() error {
+	var v validator
+
+	validateCurrency(&v, "amount.currency", r.Amount.Currency)
+	validateTaxes(&v, "amount.taxes", r.Amount.Taxes)
+	v.check(r.Amount.TipAmount >= 0, "amount.tip_amount", "must not be negative")
+	v.check(r.Amount.TotalAmount > 0, "amount.total_amount", "must be greater than zero")
+	validateAmountBreakdown(&v, "amount.total_amount", r.Amount.TotalMoney(), r.Amount.Taxes, r.Amount.TipMoney())
+
+	if strings.TrimSpace(r.UserEmail) == "" {
+		v.add("user_email", "is required")
+	} else {
+		v.check(isValidEmail(r.UserEmail), "user_email", "must be a valid email address")
+	}
+
+	v.check(slices.Contains(integrationMethods, r.PaymentMethod),
+		"payment_method", "%q is not available for the integrations API", r.PaymentMethod)
+	v.check(strings.TrimSpace(r.TerminalModel) != "", "terminal_model", "is required")
+	v.check(strings.TrimSpace(r.TerminalSerial) != "", "terminal_serial", "is required")
+	v.check(strings.TrimSpace(r.Reference) != "", "reference", "is required")
+
+	if r.Payer != nil {
+		if r.Payer.Email != "" {
+			v.check(isValidEmail(r.Payer.Email), "payer.email", "must be a valid email address")
+		}
+		if r.Payer.PhoneNumber != "" {
+			v.check(isValidPhoneNumber(r.Payer.PhoneNumber), "payer.phone_number", "must have 7 to 15 digits, optionally prefixed by +")
+		}
+		if r.Payer.Document != nil {
+			validateDocument(&v, "payer.document", r.Payer.Document.DocumentType, r.Payer.Document.DocumentNumber)
+		}
+	}
+
+	return v.err()
+}
@@ -0,0 +1,97 @@
+package definitions
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreatePaymentForIntegrationsAPIRequestValidate(t *testing.T) {
+	valid := func() CreatePaymentForIntegrationsAPIRequest {
+		return CreatePaymentForIntegrationsAPIRequest{
+			Amount: IntegrationAmount{
+				Currency:    CurrencyTypeCOP,
+				Taxes:       []Tax{{Type: TaxTypeIVA, Base: 8403, Value: 1597}},
+				TipAmount:   1000,
+				TotalAmount: 11000,
+			},
+			UserEmail:      "seller@merchant.com",
+			PaymentMethod:  PaymentMethodPos,
+			TerminalModel:  "N86",
+			TerminalSerial: "N860W000000",
+			Reference:      "d9b10690-981d-494d-bcb0-66a1dacab51d",
+			Payer: &IntegrationPayer{
+				Email:       "customer@example.com",
+				PhoneNumber: "+573100000000",
+				Document:    &IntegrationPayerDocument{DocumentType: DocumentTypeNit, DocumentNumber: "800197268-4"},
+			},
+		}
+	}
+
+	t.Run("valid requests", func(t *testing.T) {
+		assert.NoError(t, valid().Validate())
+
+		req := valid()
+		req.PaymentMethod = ""
+		req.Payer = nil
+		assert.NoError(t, req.Validate())
+	})
+
+	t.Run("invalid fields", func(t *testing.T) {
+		cases := map[string]func(r *CreatePaymentForIntegrationsAPIRequest){
+			"amount.currency":                func(r *CreatePaymentForIntegrationsAPIRequest) { r.Amount.Currency = "" },
+			"amount.taxes[0].value":          func(r *CreatePaymentForIntegrationsAPIRequest) { r.Amount.Taxes[0].Value = -1 },
+			"amount.total_amount":            func(r *CreatePaymentForIntegrationsAPIRequest) { r.Amount.TotalAmount = 10999.99 },
+			"user_email":                     func(r *CreatePaymentForIntegrationsAPIRequest) { r.UserEmail = " " },
+			"payment_method":                 func(r *CreatePaymentForIntegrationsAPIRequest) { r.PaymentMethod = PaymentMethodPse },
+			"terminal_model":                 func(r *CreatePaymentForIntegrationsAPIRequest) { r.TerminalModel = "" },
+			"terminal_serial":                func(r *CreatePaymentForIntegrationsAPIRequest) { r.TerminalSerial = "" },
+			"reference":                      func(r *CreatePaymentForIntegrationsAPIRequest) { r.Reference = "" },
+			"payer.email":                    func(r *CreatePaymentForIntegrationsAPIRequest) { r.Payer.Email = "customer" },
+			"payer.phone_number":             func(r *CreatePaymentForIntegrationsAPIRequest) { r.Payer.PhoneNumber = "310-000-0000" },
+			"payer.document.document_type":   func(r *CreatePaymentForIntegrationsAPIRequest) { r.Payer.Document.DocumentType = "RUT" },
+			"payer.document.document_number": func(r *CreatePaymentForIntegrationsAPIRequest) { r.Payer.Document.DocumentNumber = "800197268-5" },
+		}
+
+		for field, mutate := range cases {
+			t.Run(field, func(t *testing.T) {
+				req := valid()
+				mutate(&req)
+
+				var validationErr *ValidationError
+				require.ErrorAs(t, req.Validate(), &validationErr)
+				assert.Len(t, validationErr.Errors, 1)
+				assert.True(t, validationErr.HasField(field), validationErr.Error())
+			})
+		}
+	})
+
+	t.Run("document numbers", func(t *testing.T) {
+		cases := []struct {
+			documentType DocumentType
+			number       string
+			valid        bool
+		}{
+			{DocumentTypeNit, "800197268-4", true},
+			{DocumentTypeNit, "890903938-8", true},
+			{DocumentTypeNit, "800197268", true},
+			{DocumentTypeNit, "800197268-0", false},
+			{DocumentTypeNit, "800197268-", false},
+			{DocumentTypeNit, "80019726A", false},
+			{DocumentTypeCedula, "1010140000", true},
+			{DocumentTypeCedula, "10101400001", false},
+			{DocumentTypeCedula, "CC123456", false},
+			{DocumentTypeTarjetaIdentidad, "1010140000123", true},
+			{DocumentTypePasaporte, "AB123456", true},
+			{DocumentTypePasaporte, "AB-123456", false},
+			{DocumentTypeCedulaExtranjeria, "123", false},
+		}
+
+		for _, c := range cases {
+			var v validator
+			validateDocument(&v, "document", c.documentType, c.number)
+			assert.Equal(t, c.valid, v.err() == nil, "%s %q", c.documentType, c.number)
+		}
+	})
+}
@@ -1,5 +1,14 @@
 package definitions
 
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
 // AmountType represents whether the payment amount is open or closed.
 type AmountType string
 
@@ -16,6 +25,21 @@ type Amount struct {
 	TotalAmount float64      `json:"total_amount"`    // Total transaction amount including taxes and tips.
 }
 
+// TotalMoney returns the total amount as an exact Money amount.
+func (a Amount) TotalMoney() Money {
+	return MoneyFromFloat(a.TotalAmount, a.Currency)
+}
+
+// TipMoney returns the tip amount as an exact Money amount.
+func (a Amount) TipMoney() Money {
+	return MoneyFromFloat(a.TipAmount, a.Currency)
+}
+
+// TaxesMoney returns the sum of the values of the taxes as an exact Money amount.
+func (a Amount) TaxesMoney() Money {
+	return sumTaxValues(a.Taxes, a.Currency)
+}
+
 // CreatePaymentLinkRequest represents a payment request to the Bold API
 type CreatePaymentLinkRequest struct {
 	AmountType     AmountType      `json:"amount_type"`               // Required: OPEN (payer decides amount) or CLOSE (merchant sets amount).
@@ -27,3 +51,66 @@ type CreatePaymentLinkRequest struct {
 	PayerEmail     string          `json:"payer_email,omitempty"`     // Optional: Email to send the payment link to.
 	ImageURL       string          `json:"image_url,omitempty"`       // Optional: Product image URL (must be https:// and end with .png or .jpg).
 }
+
+// paymentLinkMethods are the payment methods available for payment links.
+var paymentLinkMethods = []PaymentMethod{
+	PaymentMethodCreditCard,
+	PaymentMethodPse,
+	PaymentMethodBotonBancolombia,
+	PaymentMethodNequi,
+}
+
+// Validate checks the request against the rules of the Bold API, so mistakes
+// are detected before sending it. It returns a *ValidationError listing every
+// invalid field, or nil if the request is valid.
+func (r CreatePaymentLinkRequest) Validate() error {
+	var v validator
+
+	switch r.AmountType {
+	case AmountTypeClose:
+		v.check(r.Amount != nil, "amount", "is required when amount_type is %s", AmountTypeClose)
+	case AmountTypeOpen:
+	default:
+		v.add("amount_type", "must be %s or %s", AmountTypeOpen, AmountTypeClose)
+	}
+
+	if r.Amount != nil {
+		validateCurrency(&v, "amount.currency", r.Amount.Currency)
+		validateTaxes(&v, "amount.taxes", r.Amount.Taxes)
+		v.check(r.Amount.TipAmount >= 0, "amount.tip_amount", "must not be negative")
+		v.check(r.Amount.TotalAmount > 0, "amount.total_amount", "must be greater than zero")
+	}
+
+	if r.Description != "" {
+		length := utf8.RuneCountInString(r.Description)
+		v.check(length >= 2 && length <= 100, "description", "must be between 2 and 100 characters")
+	}
+
+	if r.ExpirationDate != 0 {
+		v.check(r.ExpirationDate > time.Now().UnixNano(), "expiration_date", "must be in the future (in Unix nanoseconds)")
+	}
+
+	if r.CallbackURL != "" {
+		v.check(isHTTPSURL(r.CallbackURL), "callback_url", "must be a valid URL starting with https://")
+	}
+
+	if r.ImageURL != "" {
+		path := ""
+		if parsed, err := url.Parse(r.ImageURL); err == nil {
+			path = strings.ToLower(parsed.Path)
+		}
+		v.check(isHTTPSURL(r.ImageURL) && (strings.HasSuffix(path, ".png") || strings.HasSuffix(path, ".jpg")),
+			"image_url", "must be a valid URL starting with https:// and ending with .png or .jpg")
+	}
+
+	for i, method := range r.PaymentMethods {
+		v.check(slices.Contains(paymentLinkMethods, method),
+			fmt.Sprintf("payment_methods[%d]", i), "%q is not available for payment links", method)
+	}
+
+	if r.PayerEmail != "" {
+		v.check(isValidEmail(r.PayerEmail), "payer_email", "must be a valid email address")
+	}
+
+	return v.err()
+}
@@ -0,0 +1,77 @@
+package definitions
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreatePaymentLinkRequestValidate(t *testing.T) {
+	valid := func() CreatePaymentLinkRequest {
+		return CreatePaymentLinkRequest{
+			AmountType: AmountTypeClose,
+			Amount: &Amount{
+				Currency:    CurrencyTypeCOP,
+				Taxes:       []Tax{{Type: TaxTypeIVA, Base: 8403, Value: 1597}},
+				TotalAmount: 10000,
+			},
+			Description:    "Description of product or service",
+			ExpirationDate: time.Now().Add(time.Hour).UnixNano(),
+			CallbackURL:    "https://example.com/callback",
+			PaymentMethods: []PaymentMethod{PaymentMethodPse, PaymentMethodNequi},
+			PayerEmail:     "johndoe@example.com",
+			ImageURL:       "https://example.com/images/product.PNG",
+		}
+	}
+
+	t.Run("valid requests", func(t *testing.T) {
+		assert.NoError(t, valid().Validate())
+		assert.NoError(t, CreatePaymentLinkRequest{AmountType: AmountTypeOpen}.Validate())
+	})
+
+	t.Run("invalid fields", func(t *testing.T) {
+		cases := map[string]func(r *CreatePaymentLinkRequest){
+			"amount_type":          func(r *CreatePaymentLinkRequest) { r.AmountType = "FIXED" },
+			"amount":               func(r *CreatePaymentLinkRequest) { r.Amount = nil },
+			"amount.currency":      func(r *CreatePaymentLinkRequest) { r.Amount.Currency = "EUR" },
+			"amount.taxes[0].type": func(r *CreatePaymentLinkRequest) { r.Amount.Taxes[0].Type = "ICA" },
+			"amount.tip_amount":    func(r *CreatePaymentLinkRequest) { r.Amount.TipAmount = -1 },
+			"amount.total_amount":  func(r *CreatePaymentLinkRequest) { r.Amount.TotalAmount = 0 },
+			"description":          func(r *CreatePaymentLinkRequest) { r.Description = "x" },
+			"expiration_date":      func(r *CreatePaymentLinkRequest) { r.ExpirationDate = time.Now().Add(-time.Minute).UnixNano() },
+			"callback_url":         func(r *CreatePaymentLinkRequest) { r.CallbackURL = "http://example.com/callback" },
+			"image_url":            func(r *CreatePaymentLinkRequest) { r.ImageURL = "https://example.com/product.gif" },
+			"payment_methods[1]":   func(r *CreatePaymentLinkRequest) { r.PaymentMethods[1] = PaymentMethodDaviplata },
+			"payer_email":          func(r *CreatePaymentLinkRequest) { r.PayerEmail = "John <johndoe@example.com>" },
+		}
+
+		for field, mutate := range cases {
+			t.Run(field, func(t *testing.T) {
+				req := valid()
+				mutate(&req)
+
+				var validationErr *ValidationError
+				require.ErrorAs(t, req.Validate(), &validationErr)
+				assert.Len(t, validationErr.Errors, 1)
+				assert.True(t, validationErr.HasField(field), validationErr.Error())
+			})
+		}
+	})
+
+	t.Run("every invalid field is reported", func(t *testing.T) {
+		req := CreatePaymentLinkRequest{
+			AmountType:  AmountTypeClose,
+			Description: "x",
+			CallbackURL: "ftp://example.com",
+		}
+
+		var validationErr *ValidationError
+		require.ErrorAs(t, req.Validate(), &validationErr)
+		assert.Len(t, validationErr.Errors, 3)
+		assert.Equal(t, "invalid request: amount: is required when amount_type is CLOSE; "+
+			"description: must be between 2 and 100 characters; "+
+			"callback_url: must be a valid URL starting with https://", validationErr.Error())
+	})
+}
@@ -61,6 +61,27 @@ type PaymentLinkDetails struct {
 	IsSandbox bool `json:"is_sandbox"`
 }
 
+// TotalMoney returns the total amount as an exact Money amount.
+// Bold processes the payment links in COP.
+func (d PaymentLinkDetails) TotalMoney() Money {
+	return MoneyFromFloat(d.Total, CurrencyTypeCOP)
+}
+
+// SubtotalMoney returns the subtotal as an exact Money amount.
+func (d PaymentLinkDetails) SubtotalMoney() Money {
+	return MoneyFromFloat(d.Subtotal, CurrencyTypeCOP)
+}
+
+// TipMoney returns the tip amount as an exact Money amount.
+func (d PaymentLinkDetails) TipMoney() Money {
+	return MoneyFromFloat(d.TipAmount, CurrencyTypeCOP)
+}
+
+// TaxesMoney returns the sum of the values of the taxes as an exact Money amount.
+func (d PaymentLinkDetails) TaxesMoney() Money {
+	return sumTaxValues(d.Taxes, CurrencyTypeCOP)
+}
+
 // GetPaymentLinkDataResponse represents the response from retrieving payment link information.
 // For this specific endpoint, the API returns the data directly without wrapping it in a payload.
 type GetPaymentLinkDataResponse struct {
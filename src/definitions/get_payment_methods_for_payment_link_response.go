@@ -1,5 +1,7 @@
 package definitions
 
+import "slices"
+
 // PaymentMethodLimits represents the minimum and maximum amount limits for a payment method.
 type PaymentMethodLimits struct {
 	// Min is the minimum amount that can be processed with this payment method.
@@ -9,9 +11,39 @@ type PaymentMethodLimits struct {
 	Max int64 `json:"max"`
 }
 
+// Allows reports whether the amount is within the limits. The limits are
+// expressed in pesos and are inclusive; a zero Max means there is no maximum.
+// Amounts in other currencies are never allowed, while amounts without a
+// currency are compatible with the limits, like in the Money operations.
+func (l PaymentMethodLimits) Allows(amount Money) bool {
+	comparison, err := amount.Compare(NewMoney(l.Min*minorUnitsPerMajor, CurrencyTypeCOP))
+	if err != nil || comparison < 0 {
+		return false
+	}
+	if l.Max == 0 {
+		return true
+	}
+
+	comparison, _ = amount.Compare(NewMoney(l.Max*minorUnitsPerMajor, CurrencyTypeCOP))
+	return comparison <= 0
+}
+
 // PaymentMethodsMap represents a map of available payment methods and their limits.
 type PaymentMethodsMap map[PaymentMethod]PaymentMethodLimits
 
+// EligibleMethods returns the payment methods whose limits allow the amount,
+// sorted alphabetically.
+func (m PaymentMethodsMap) EligibleMethods(amount Money) []PaymentMethod {
+	var methods []PaymentMethod
+	for method, limits := range m {
+		if limits.Allows(amount) {
+			methods = append(methods, method)
+		}
+	}
+	slices.Sort(methods)
+	return methods
+}
+
 // PaymentMethodsData represents the available payment methods with their respective limits.
 type PaymentMethodsData struct {
 	// PaymentMethods contains a map of payment method types to their limits.
@@ -0,0 +1,52 @@
+package definitions
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPaymentMethodsMap(t *testing.T) {
+	methods := PaymentMethodsMap{
+		PaymentMethodCreditCard:       {Min: 1000, Max: 0},
+		PaymentMethodPse:              {Min: 1000, Max: 5000000},
+		PaymentMethodNequi:            {Min: 1000, Max: 2000000},
+		PaymentMethodBotonBancolombia: {Min: 5000, Max: 10000000},
+	}
+
+	t.Run("limits are inclusive", func(t *testing.T) {
+		limits := methods[PaymentMethodPse]
+		assert.True(t, limits.Allows(NewMoney(1000*100, CurrencyTypeCOP)))
+		assert.True(t, limits.Allows(NewMoney(5000000*100, CurrencyTypeCOP)))
+		assert.False(t, limits.Allows(NewMoney(1000*100-1, CurrencyTypeCOP)))
+		assert.False(t, limits.Allows(NewMoney(5000000*100+1, CurrencyTypeCOP)))
+	})
+
+	t.Run("limits only allow COP amounts", func(t *testing.T) {
+		limits := methods[PaymentMethodPse]
+		assert.False(t, limits.Allows(NewMoney(2000*100, CurrencyTypeUSD)))
+		assert.Empty(t, methods.EligibleMethods(NewMoney(2000*100, CurrencyTypeUSD)))
+	})
+
+	t.Run("amounts without a currency are checked as COP", func(t *testing.T) {
+		limits := methods[PaymentMethodPse]
+		assert.True(t, limits.Allows(NewMoney(2000*100, "")))
+		assert.False(t, limits.Allows(NewMoney(5000000*100+1, "")))
+
+		var decoded Money
+		require.NoError(t, json.Unmarshal([]byte(`2000`), &decoded))
+		assert.True(t, limits.Allows(decoded))
+	})
+
+	t.Run("eligible methods", func(t *testing.T) {
+		assert.Equal(t,
+			[]PaymentMethod{PaymentMethodCreditCard, PaymentMethodNequi, PaymentMethodPse},
+			methods.EligibleMethods(NewMoney(2000*100, CurrencyTypeCOP)))
+		assert.Equal(t,
+			[]PaymentMethod{PaymentMethodBotonBancolombia, PaymentMethodCreditCard},
+			methods.EligibleMethods(NewMoney(6000000*100, CurrencyTypeCOP)))
+		assert.Empty(t, methods.EligibleMethods(NewMoney(999*100, CurrencyTypeCOP)))
+	})
+}
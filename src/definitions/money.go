@@ -0,0 +1,295 @@
+package definitions
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// minorUnitsPerMajor is the number of minor units (cents) in one unit of the
+// supported currencies (COP and USD).
+const minorUnitsPerMajor = 100
+
+// ErrCurrencyMismatch is returned when operating on amounts of different currencies.
+var ErrCurrencyMismatch = errors.New("currency mismatch")
+
+// Money represents an exact monetary amount stored as an integer number of
+// minor units (cents), avoiding the rounding errors of float64 amounts.
+//
+// Money is encoded in JSON as a plain number in major units (e.g., 8403.5),
+// like the numeric amount fields of the Bold API. Since the currency is not
+// part of that number, it is left empty when decoding; an empty currency is
+// compatible with any other currency in the arithmetic operations.
+type Money struct {
+	minorUnits int64
+	currency   CurrencyType
+}
+
+// NewMoney creates a Money from an amount expressed in minor units (cents).
+func NewMoney(minorUnits int64, currency CurrencyType) Money {
+	return Money{minorUnits: minorUnits, currency: currency}
+}
+
+// MoneyFromFloat creates a Money from an amount expressed in major units,
+// rounding half away from zero to the nearest minor unit.
+func MoneyFromFloat(amount float64, currency CurrencyType) Money {
+	return Money{minorUnits: int64(math.Round(amount * minorUnitsPerMajor)), currency: currency}
+}
+
+// ParseMoney creates a Money from a decimal string in major units (e.g., "8403.50").
+// It fails if the string has more decimals than the currency supports.
+func ParseMoney(value string, currency CurrencyType) (Money, error) {
+	minorUnits, err := parseMinorUnits(value)
+	if err != nil {
+		return Money{}, err
+	}
+	return Money{minorUnits: minorUnits, currency: currency}, nil
+}
+
+// MinorUnits returns the amount in minor units (cents).
+func (m Money) MinorUnits() int64 {
+	return m.minorUnits
+}
+
+// Currency returns the currency of the amount.
+func (m Money) Currency() CurrencyType {
+	return m.currency
+}
+
+// WithCurrency returns a copy of the amount with the given currency.
+func (m Money) WithCurrency(currency CurrencyType) Money {
+	m.currency = currency
+	return m
+}
+
+// Float64 returns the amount in major units, to fill the float64 fields of the
+// request structures.
+func (m Money) Float64() float64 {
+	return float64(m.minorUnits) / minorUnitsPerMajor
+}
+
+// IsZero reports whether the amount is zero.
+func (m Money) IsZero() bool {
+	return m.minorUnits == 0
+}
+
+// IsNegative reports whether the amount is lower than zero.
+func (m Money) IsNegative() bool {
+	return m.minorUnits < 0
+}
+
+// Add returns the sum of both amounts.
+func (m Money) Add(other Money) (Money, error) {
+	currency, err := m.commonCurrency(other)
+	if err != nil {
+		return Money{}, err
+	}
+	return Money{minorUnits: m.minorUnits + other.minorUnits, currency: currency}, nil
+}
+
+// Sub returns the difference between both amounts.
+func (m Money) Sub(other Money) (Money, error) {
+	currency, err := m.commonCurrency(other)
+	if err != nil {
+		return Money{}, err
+	}
+	return Money{minorUnits: m.minorUnits - other.minorUnits, currency: currency}, nil
+}
+
+// Mul returns the amount multiplied by the given factor.
+func (m Money) Mul(factor int64) Money {
+	return Money{minorUnits: m.minorUnits * factor, currency: m.currency}
+}
+
+// Neg returns the amount with the opposite sign.
+func (m Money) Neg() Money {
+	return Money{minorUnits: -m.minorUnits, currency: m.currency}
+}
+
+// Compare returns -1, 0 or +1 depending on whether the amount is lower than,
+// equal to or greater than the other one.
+func (m Money) Compare(other Money) (int, error) {
+	if _, err := m.commonCurrency(other); err != nil {
+		return 0, err
+	}
+
+	switch {
+	case m.minorUnits < other.minorUnits:
+		return -1, nil
+	case m.minorUnits > other.minorUnits:
+		return 1, nil
+	default:
+		return 0, nil
+	}
+}
+
+// Equal reports whether both amounts are equal and have compatible currencies.
+func (m Money) Equal(other Money) bool {
+	comparison, err := m.Compare(other)
+	return err == nil && comparison == 0
+}
+
+// Allocate splits the amount proportionally to the given ratios. The parts
+// always sum exactly to the original amount: the minor units lost to rounding
+// are assigned, one by one, to the first parts.
+func (m Money) Allocate(ratios ...int64) ([]Money, error) {
+	var total int64
+	for _, ratio := range ratios {
+		if ratio < 0 {
+			return nil, fmt.Errorf("invalid negative ratio: %d", ratio)
+		}
+		total += ratio
+	}
+	if total == 0 {
+		return nil, errors.New("the sum of the ratios must be greater than zero")
+	}
+
+	parts := make([]Money, len(ratios))
+	remainder := m.minorUnits
+	for i, ratio := range ratios {
+		parts[i] = Money{minorUnits: m.minorUnits * ratio / total, currency: m.currency}
+		remainder -= parts[i].minorUnits
+	}
+
+	step := int64(1)
+	if remainder < 0 {
+		step = -1
+	}
+	for i := 0; remainder != 0; i = (i + 1) % len(parts) {
+		if ratios[i] == 0 {
+			continue
+		}
+		parts[i].minorUnits += step
+		remainder -= step
+	}
+
+	return parts, nil
+}
+
+// String returns the amount in major units followed by its currency (e.g., "8403.50 COP").
+func (m Money) String() string {
+	value := formatMinorUnits(m.minorUnits, true)
+	if m.currency == "" {
+		return value
+	}
+	return value + " " + string(m.currency)
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(formatMinorUnits(m.minorUnits, false)), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	value := string(data)
+	if value == "null" {
+		return nil
+	}
+
+	minorUnits, err := parseMinorUnits(value)
+	if err != nil {
+		return err
+	}
+
+	m.minorUnits = minorUnits
+	return nil
+}
+
+// SumMoney returns the sum of the given amounts.
+func SumMoney(values ...Money) (Money, error) {
+	var total Money
+	for _, value := range values {
+		var err error
+		if total, err = total.Add(value); err != nil {
+			return Money{}, err
+		}
+	}
+	return total, nil
+}
+
+// commonCurrency returns the currency of the result of operating on both
+// amounts, failing if their currencies are not compatible.
+func (m Money) commonCurrency(other Money) (CurrencyType, error) {
+	switch {
+	case m.currency == other.currency || other.currency == "":
+		return m.currency, nil
+	case m.currency == "":
+		return other.currency, nil
+	default:
+		return "", fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, m.currency, other.currency)
+	}
+}
+
+// formatMinorUnits formats an amount in minor units as a decimal number in
+// major units. If fixed is false, the decimals are omitted when possible.
+func formatMinorUnits(minorUnits int64, fixed bool) string {
+	sign := ""
+	if minorUnits < 0 {
+		sign = "-"
+	}
+
+	// Use unsigned values to support math.MinInt64
+	abs := uint64(minorUnits)
+	if minorUnits < 0 {
+		abs = -abs
+	}
+
+	major, minor := abs/minorUnitsPerMajor, abs%minorUnitsPerMajor
+	if !fixed && minor == 0 {
+		return fmt.Sprintf("%s%d", sign, major)
+	}
+
+	decimals := fmt.Sprintf("%02d", minor)
+	if !fixed {
+		decimals = strings.TrimRight(decimals, "0")
+	}
+	return fmt.Sprintf("%s%d.%s", sign, major, decimals)
+}
+
+// parseMinorUnits parses a decimal number in major units (e.g., "8403.5" or
+// "1e3") into minor units, without losing precision.
+func parseMinorUnits(value string) (int64, error) {
+	value = strings.TrimSpace(value)
+
+	// Numbers in exponential notation are converted through float64 and must be exact
+	if strings.ContainsAny(value, "eE") {
+		amount, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid amount %q: %w", value, err)
+		}
+
+		minorUnits := math.Round(amount * minorUnitsPerMajor)
+		if math.Abs(minorUnits-amount*minorUnitsPerMajor) > 1e-6 {
+			return 0, fmt.Errorf("invalid amount %q: too many decimals", value)
+		}
+		return int64(minorUnits), nil
+	}
+
+	integerPart, decimalPart, _ := strings.Cut(value, ".")
+	if strings.TrimPrefix(integerPart, "-") == "" && decimalPart == "" {
+		return 0, fmt.Errorf("invalid amount %q", value)
+	}
+	if len(decimalPart) > 2 {
+		// Allow trailing zeros (e.g., "10.500")
+		if strings.Trim(decimalPart[2:], "0") != "" {
+			return 0, fmt.Errorf("invalid amount %q: too many decimals", value)
+		}
+		decimalPart = decimalPart[:2]
+	}
+
+	negative := strings.HasPrefix(integerPart, "-")
+	digits := strings.TrimPrefix(integerPart, "-") + decimalPart + strings.Repeat("0", 2-len(decimalPart))
+
+	minorUnits, err := strconv.ParseInt(digits, 10, 64)
+	if err != nil || strings.ContainsAny(digits, "+-") {
+		return 0, fmt.Errorf("invalid amount %q", value)
+	}
+
+	if negative {
+		minorUnits = -minorUnits
+	}
+	return minorUnits, nil
+}
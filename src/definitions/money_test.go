@@ -0,0 +1,120 @@
+package definitions
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMoney(t *testing.T) {
+	t.Run("constructors", func(t *testing.T) {
+		assert.Equal(t, int64(840350), NewMoney(840350, CurrencyTypeCOP).MinorUnits())
+		assert.Equal(t, int64(30), MoneyFromFloat(0.1+0.2, CurrencyTypeCOP).MinorUnits())
+		assert.Equal(t, int64(-1), MoneyFromFloat(-0.005, CurrencyTypeCOP).MinorUnits())
+
+		money, err := ParseMoney("8403.5", CurrencyTypeCOP)
+		require.NoError(t, err)
+		assert.Equal(t, int64(840350), money.MinorUnits())
+		assert.Equal(t, CurrencyTypeCOP, money.Currency())
+
+		for _, invalid := range []string{"", "-", "abc", "1.234", "1.2.3", "+5", "1.-5"} {
+			_, err := ParseMoney(invalid, CurrencyTypeCOP)
+			assert.Error(t, err, "ParseMoney(%q)", invalid)
+		}
+	})
+
+	t.Run("arithmetic", func(t *testing.T) {
+		base := NewMoney(840300, CurrencyTypeCOP)
+		tax := NewMoney(159700, CurrencyTypeCOP)
+
+		total, err := base.Add(tax)
+		require.NoError(t, err)
+		assert.Equal(t, "10000.00 COP", total.String())
+
+		difference, err := total.Sub(tax)
+		require.NoError(t, err)
+		assert.True(t, difference.Equal(base))
+
+		assert.Equal(t, int64(300), NewMoney(100, CurrencyTypeCOP).Mul(3).MinorUnits())
+		assert.True(t, base.Neg().IsNegative())
+		assert.True(t, NewMoney(0, CurrencyTypeUSD).IsZero())
+
+		sum, err := SumMoney(base, tax, NewMoney(100, ""))
+		require.NoError(t, err)
+		assert.Equal(t, int64(1000100), sum.MinorUnits())
+		assert.Equal(t, CurrencyTypeCOP, sum.Currency())
+	})
+
+	t.Run("currency mismatch", func(t *testing.T) {
+		cop := NewMoney(100, CurrencyTypeCOP)
+		usd := NewMoney(100, CurrencyTypeUSD)
+
+		_, err := cop.Add(usd)
+		assert.ErrorIs(t, err, ErrCurrencyMismatch)
+
+		_, err = cop.Compare(usd)
+		assert.ErrorIs(t, err, ErrCurrencyMismatch)
+		assert.False(t, cop.Equal(usd))
+	})
+
+	t.Run("comparison", func(t *testing.T) {
+		lower := NewMoney(100, CurrencyTypeCOP)
+		greater := NewMoney(200, CurrencyTypeCOP)
+
+		comparison, err := lower.Compare(greater)
+		require.NoError(t, err)
+		assert.Equal(t, -1, comparison)
+
+		comparison, err = greater.Compare(lower)
+		require.NoError(t, err)
+		assert.Equal(t, 1, comparison)
+	})
+
+	t.Run("allocate", func(t *testing.T) {
+		parts, err := NewMoney(1000000, CurrencyTypeCOP).Allocate(1, 1, 1)
+		require.NoError(t, err)
+		assert.Equal(t, []int64{333334, 333333, 333333}, []int64{parts[0].MinorUnits(), parts[1].MinorUnits(), parts[2].MinorUnits()})
+
+		parts, err = NewMoney(-5, CurrencyTypeCOP).Allocate(0, 1, 1)
+		require.NoError(t, err)
+		assert.Equal(t, []int64{0, -3, -2}, []int64{parts[0].MinorUnits(), parts[1].MinorUnits(), parts[2].MinorUnits()})
+
+		_, err = NewMoney(100, CurrencyTypeCOP).Allocate(0, 0)
+		assert.Error(t, err)
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		type payload struct {
+			Total Money `json:"total"`
+			Tip   Money `json:"tip"`
+		}
+
+		data, err := json.Marshal(payload{Total: NewMoney(1000000, CurrencyTypeCOP), Tip: NewMoney(-1050, CurrencyTypeCOP)})
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"total":10000,"tip":-10.5}`, string(data))
+
+		var decoded payload
+		require.NoError(t, json.Unmarshal([]byte(`{"total":8403.07,"tip":1e2}`), &decoded))
+		assert.Equal(t, int64(840307), decoded.Total.MinorUnits())
+		assert.Equal(t, int64(10000), decoded.Tip.MinorUnits())
+
+		assert.Error(t, json.Unmarshal([]byte(`{"total":"abc"}`), &decoded))
+	})
+
+	t.Run("accessors", func(t *testing.T) {
+		amount := Amount{
+			Currency:    CurrencyTypeCOP,
+			Taxes:       []Tax{{Type: TaxTypeIVA, Base: 8403.36, Value: 1596.64}},
+			TipAmount:   0.1,
+			TotalAmount: 10000.1,
+		}
+
+		subtotal, err := amount.TotalMoney().Sub(amount.TaxesMoney())
+		require.NoError(t, err)
+		subtotal, err = subtotal.Sub(amount.TipMoney())
+		require.NoError(t, err)
+		assert.True(t, subtotal.Equal(amount.Taxes[0].BaseMoney(CurrencyTypeCOP)))
+	})
+}
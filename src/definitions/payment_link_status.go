@@ -0,0 +1,125 @@
+package definitions
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// paymentLinkTransitions is the graph of the status transitions of a payment link:
+//
+//	ACTIVE     -> PROCESSING, PAID, REJECTED, EXPIRED, CANCELED
+//	PROCESSING -> ACTIVE, PAID, REJECTED
+//
+// A payment link goes back from PROCESSING to ACTIVE when a payment attempt fails
+// and the link can still be paid. Transitions from ACTIVE to PAID or REJECTED
+// happen when the PROCESSING status is not observed. PAID, REJECTED, EXPIRED and
+// CANCELED are terminal: they have no outgoing transitions.
+var paymentLinkTransitions = map[PaymentLinkStatus][]PaymentLinkStatus{
+	PaymentLinkStatusActive: {
+		PaymentLinkStatusProcessing,
+		PaymentLinkStatusPaid,
+		PaymentLinkStatusRejected,
+		PaymentLinkStatusExpired,
+		PaymentLinkStatusCanceled,
+	},
+	PaymentLinkStatusProcessing: {
+		PaymentLinkStatusActive,
+		PaymentLinkStatusPaid,
+		PaymentLinkStatusRejected,
+	},
+}
+
+// IsKnown reports whether the status is one of the statuses documented by Bold.
+func (s PaymentLinkStatus) IsKnown() bool {
+	switch s {
+	case PaymentLinkStatusActive, PaymentLinkStatusProcessing, PaymentLinkStatusPaid,
+		PaymentLinkStatusRejected, PaymentLinkStatusExpired, PaymentLinkStatusCanceled:
+		return true
+	}
+	return false
+}
+
+// IsTerminal reports whether the status is final, so the payment link will not change anymore.
+func (s PaymentLinkStatus) IsTerminal() bool {
+	switch s {
+	case PaymentLinkStatusPaid, PaymentLinkStatusRejected, PaymentLinkStatusExpired, PaymentLinkStatusCanceled:
+		return true
+	}
+	return false
+}
+
+// IsSuccessful reports whether the payment link was paid.
+func (s PaymentLinkStatus) IsSuccessful() bool {
+	return s == PaymentLinkStatusPaid
+}
+
+// CanTransitionTo reports whether a payment link can move from this status to
+// the next one, according to the transition graph documented above. Staying in
+// the same status is always allowed, and unknown statuses cannot transition.
+func (s PaymentLinkStatus) CanTransitionTo(next PaymentLinkStatus) bool {
+	if !s.IsKnown() || !next.IsKnown() {
+		return false
+	}
+	return s == next || slices.Contains(paymentLinkTransitions[s], next)
+}
+
+// ErrInvalidPaymentLinkTransition is matched (using errors.Is) by the errors
+// returned by CheckPaymentLinkTransition.
+var ErrInvalidPaymentLinkTransition = errors.New("invalid payment link transition")
+
+// PaymentLinkTransitionError describes an impossible change between two
+// snapshots of a payment link.
+type PaymentLinkTransitionError struct {
+	// PaymentLinkID is the identifier of the payment link.
+	PaymentLinkID string
+
+	// From is the status of the previous snapshot.
+	From PaymentLinkStatus
+
+	// To is the status of the current snapshot.
+	To PaymentLinkStatus
+
+	// Reason describes why the change is impossible.
+	Reason string
+}
+
+// Error implements the error interface.
+func (e *PaymentLinkTransitionError) Error() string {
+	return fmt.Sprintf("%s: %s -> %s: %s", ErrInvalidPaymentLinkTransition, e.From, e.To, e.Reason)
+}
+
+// Is makes the error match ErrInvalidPaymentLinkTransition.
+func (e *PaymentLinkTransitionError) Is(target error) bool {
+	return target == ErrInvalidPaymentLinkTransition
+}
+
+// CheckPaymentLinkTransition compares two snapshots of the same payment link,
+// taken in order, and returns a *PaymentLinkTransitionError if the change
+// between them is impossible (e.g., PAID -> ACTIVE), or nil otherwise.
+// It is intended to detect anomalies during reconciliations.
+func CheckPaymentLinkTransition(previous, current PaymentLinkDetails) error {
+	transitionErr := &PaymentLinkTransitionError{
+		PaymentLinkID: current.ID,
+		From:          previous.Status,
+		To:            current.Status,
+	}
+
+	switch {
+	case previous.ID != current.ID:
+		transitionErr.Reason = fmt.Sprintf("the snapshots belong to different payment links (%s and %s)", previous.ID, current.ID)
+	case !previous.Status.IsKnown() || !current.Status.IsKnown():
+		transitionErr.Reason = "unknown status"
+	case previous.Status.IsTerminal() && previous.Status != current.Status:
+		transitionErr.Reason = fmt.Sprintf("%s is a terminal status", previous.Status)
+	case !previous.Status.CanTransitionTo(current.Status):
+		transitionErr.Reason = "the transition is not allowed"
+	case previous.Status == PaymentLinkStatusPaid && previous.TransactionID != nil &&
+		(current.TransactionID == nil || *current.TransactionID != *previous.TransactionID):
+		transitionErr.Reason = "the transaction of a paid payment link changed"
+	default:
+		return nil
+	}
+
+	return transitionErr
+}
@@ -0,0 +1,73 @@
+package definitions
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPaymentLinkStatus(t *testing.T) {
+	t.Run("classification", func(t *testing.T) {
+		assert.False(t, PaymentLinkStatusActive.IsTerminal())
+		assert.False(t, PaymentLinkStatusProcessing.IsTerminal())
+		assert.True(t, PaymentLinkStatusPaid.IsTerminal())
+		assert.True(t, PaymentLinkStatusCanceled.IsTerminal())
+
+		assert.True(t, PaymentLinkStatusPaid.IsSuccessful())
+		assert.False(t, PaymentLinkStatusRejected.IsSuccessful())
+
+		assert.False(t, PaymentLinkStatus("UNKNOWN").IsKnown())
+	})
+
+	t.Run("transitions", func(t *testing.T) {
+		assert.True(t, PaymentLinkStatusActive.CanTransitionTo(PaymentLinkStatusProcessing))
+		assert.True(t, PaymentLinkStatusActive.CanTransitionTo(PaymentLinkStatusExpired))
+		assert.True(t, PaymentLinkStatusProcessing.CanTransitionTo(PaymentLinkStatusActive))
+		assert.True(t, PaymentLinkStatusPaid.CanTransitionTo(PaymentLinkStatusPaid))
+
+		assert.False(t, PaymentLinkStatusPaid.CanTransitionTo(PaymentLinkStatusActive))
+		assert.False(t, PaymentLinkStatusProcessing.CanTransitionTo(PaymentLinkStatusCanceled))
+		assert.False(t, PaymentLinkStatusExpired.CanTransitionTo(PaymentLinkStatusCanceled))
+		assert.False(t, PaymentLinkStatusActive.CanTransitionTo("UNKNOWN"))
+	})
+
+	t.Run("snapshot comparison", func(t *testing.T) {
+		transaction, other := "TX_1", "TX_2"
+		snapshot := func(id string, status PaymentLinkStatus, transactionID *string) PaymentLinkDetails {
+			return PaymentLinkDetails{ID: id, Status: status, TransactionID: transactionID}
+		}
+
+		assert.NoError(t, CheckPaymentLinkTransition(
+			snapshot("LNK_1", PaymentLinkStatusActive, nil),
+			snapshot("LNK_1", PaymentLinkStatusPaid, &transaction)))
+		assert.NoError(t, CheckPaymentLinkTransition(
+			snapshot("LNK_1", PaymentLinkStatusPaid, &transaction),
+			snapshot("LNK_1", PaymentLinkStatusPaid, &transaction)))
+
+		invalid := []struct {
+			name              string
+			previous, current PaymentLinkDetails
+		}{
+			{"terminal status", snapshot("LNK_1", PaymentLinkStatusPaid, &transaction), snapshot("LNK_1", PaymentLinkStatusActive, nil)},
+			{"not allowed", snapshot("LNK_1", PaymentLinkStatusProcessing, nil), snapshot("LNK_1", PaymentLinkStatusExpired, nil)},
+			{"different links", snapshot("LNK_1", PaymentLinkStatusActive, nil), snapshot("LNK_2", PaymentLinkStatusActive, nil)},
+			{"unknown status", snapshot("LNK_1", PaymentLinkStatusActive, nil), snapshot("LNK_1", "REFUNDED", nil)},
+			{"transaction changed", snapshot("LNK_1", PaymentLinkStatusPaid, &transaction), snapshot("LNK_1", PaymentLinkStatusPaid, &other)},
+		}
+
+		for _, c := range invalid {
+			err := CheckPaymentLinkTransition(c.previous, c.current)
+			assert.ErrorIs(t, err, ErrInvalidPaymentLinkTransition, c.name)
+
+			var transitionErr *PaymentLinkTransitionError
+			if assert.ErrorAs(t, err, &transitionErr, c.name) {
+				assert.Equal(t, c.previous.Status, transitionErr.From)
+				assert.Equal(t, c.current.Status, transitionErr.To)
+			}
+		}
+
+		assert.EqualError(t,
+			CheckPaymentLinkTransition(snapshot("LNK_1", PaymentLinkStatusPaid, nil), snapshot("LNK_1", PaymentLinkStatusActive, nil)),
+			"invalid payment link transition: PAID -> ACTIVE: PAID is a terminal status")
+	})
+}
@@ -38,6 +38,7 @@ const (
 	PaymentMethodPos              PaymentMethod = "POS"         // Point of Sale. Only available for integrations API
 	PaymentMethodDaviplata        PaymentMethod = "DAVIPLATA"   // Only available for integrations API
 	PaymentMethodPayByLink        PaymentMethod = "PAY_BY_LINK" // Only available for integrations API
+	PaymentMethodCard             PaymentMethod = "CARD"        // Only reported in webhook notifications
 )
 
 // PayerDocument represents the identification document of the payer.
@@ -70,3 +71,22 @@ type Tax struct {
 
 // ErrorField represents a single error field in the API response.
 type ErrorField map[string]string
+
+// BaseMoney returns the base of the tax as an exact Money amount in the given currency.
+func (t Tax) BaseMoney(currency CurrencyType) Money {
+	return MoneyFromFloat(t.Base, currency)
+}
+
+// ValueMoney returns the value of the tax as an exact Money amount in the given currency.
+func (t Tax) ValueMoney(currency CurrencyType) Money {
+	return MoneyFromFloat(t.Value, currency)
+}
+
+// sumTaxValues returns the sum of the values of the given taxes as an exact Money amount.
+func sumTaxValues(taxes []Tax, currency CurrencyType) Money {
+	var total int64
+	for _, tax := range taxes {
+		total += tax.ValueMoney(currency).MinorUnits()
+	}
+	return NewMoney(total, currency)
+}
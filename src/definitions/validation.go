@@ -0,0 +1,182 @@
+package definitions
+
+import (
+	"fmt"
+	"net/mail"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// FieldError describes why a single field of a request is invalid.
+type FieldError struct {
+	// Field is the JSON path of the invalid field (e.g., "amount.total_amount").
+	Field string
+
+	// Message describes the problem.
+	Message string
+}
+
+// ValidationError is returned when a request does not satisfy the rules of the
+// Bold API. It contains every invalid field, not only the first one.
+type ValidationError struct {
+	// Errors contains one entry per invalid field.
+	Errors []FieldError
+}
+
+// Error implements the error interface.
+func (e *ValidationError) Error() string {
+	messages := make([]string, len(e.Errors))
+	for i, fieldError := range e.Errors {
+		messages[i] = fmt.Sprintf("%s: %s", fieldError.Field, fieldError.Message)
+	}
+	return "invalid request: " + strings.Join(messages, "; ")
+}
+
+// HasField reports whether the given field is among the invalid ones.
+func (e *ValidationError) HasField(field string) bool {
+	for _, fieldError := range e.Errors {
+		if fieldError.Field == field {
+			return true
+		}
+	}
+	return false
+}
+
+// validator collects the field errors of a request.
+type validator struct {
+	errors []FieldError
+}
+
+// add registers an error for the given field.
+func (v *validator) add(field string, format string, args ...any) {
+	v.errors = append(v.errors, FieldError{Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+// check registers an error for the given field if the condition is false.
+func (v *validator) check(condition bool, field string, format string, args ...any) {
+	if !condition {
+		v.add(field, format, args...)
+	}
+}
+
+// err returns the collected errors as a ValidationError, or nil if there are none.
+func (v *validator) err() error {
+	if len(v.errors) == 0 {
+		return nil
+	}
+	return &ValidationError{Errors: v.errors}
+}
+
+// isValidEmail reports whether the value is a plain email address (without display name).
+func isValidEmail(value string) bool {
+	address, err := mail.ParseAddress(value)
+	return err == nil && address.Address == value && address.Name == ""
+}
+
+// isHTTPSURL reports whether the value is an absolute URL with the https scheme.
+func isHTTPSURL(value string) bool {
+	parsed, err := url.Parse(value)
+	return err == nil && strings.HasPrefix(value, "https://") && parsed.Host != ""
+}
+
+// validateCurrency checks that the currency is supported.
+func validateCurrency(v *validator, field string, currency CurrencyType) {
+	v.check(currency == CurrencyTypeCOP || currency == CurrencyTypeUSD,
+		field, "must be %s or %s", CurrencyTypeCOP, CurrencyTypeUSD)
+}
+
+// validateTaxes checks the type and amounts of the taxes.
+func validateTaxes(v *validator, field string, taxes []Tax) {
+	for i, tax := range taxes {
+		prefix := fmt.Sprintf("%s[%d]", field, i)
+		v.check(tax.Type == TaxTypeIVA || tax.Type == TaxTypeConsumption,
+			prefix+".type", "must be %s or %s", TaxTypeIVA, TaxTypeConsumption)
+		v.check(tax.Base >= 0, prefix+".base", "must not be negative")
+		v.check(tax.Value >= 0, prefix+".value", "must not be negative")
+	}
+}
+
+// validateAmountBreakdown checks that the taxed bases, the tax values and the
+// tip do not exceed the total amount. The total may be greater than their sum,
+// since part of it may be untaxed.
+func validateAmountBreakdown(v *validator, field string, total Money, taxes []Tax, tip Money) {
+	breakdown := tip.MinorUnits()
+	for _, tax := range taxes {
+		breakdown += tax.BaseMoney("").MinorUnits() + tax.ValueMoney("").MinorUnits()
+	}
+	v.check(breakdown <= total.MinorUnits(), field,
+		"must be at least the sum of the tax bases, tax values and tip (%s)", NewMoney(breakdown, total.Currency()))
+}
+
+// isValidPhoneNumber reports whether the value has 7 to 15 digits, optionally prefixed by +.
+func isValidPhoneNumber(value string) bool {
+	digits := strings.TrimPrefix(value, "+")
+	return len(digits) >= 7 && len(digits) <= 15 && isDigits(digits)
+}
+
+// isDigits reports whether the value only contains ASCII digits.
+func isDigits(value string) bool {
+	for _, r := range value {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return value != ""
+}
+
+// isAlphanumeric reports whether the value only contains ASCII letters and digits.
+func isAlphanumeric(value string) bool {
+	for _, r := range value {
+		if (r < '0' || r > '9') && (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') {
+			return false
+		}
+	}
+	return value != ""
+}
+
+// nitWeights are the weights applied by the DIAN to the digits of a NIT,
+// starting from the rightmost one, to compute its check digit.
+var nitWeights = []int{3, 7, 13, 17, 19, 23, 29, 37, 41, 43, 47, 53, 59, 67, 71}
+
+// nitCheckDigit computes the check digit of a NIT (without the check digit).
+func nitCheckDigit(nit string) int {
+	sum := 0
+	for i := range len(nit) {
+		sum += int(nit[len(nit)-1-i]-'0') * nitWeights[i]
+	}
+	remainder := sum % 11
+	if remainder > 1 {
+		return 11 - remainder
+	}
+	return remainder
+}
+
+// validateDocument checks the format of a document number according to its type.
+// Documents issued in Colombia are numeric, while passports and foreign documents
+// may contain letters. NIT numbers may include the check digit after a dash
+// (e.g., "800197268-4"), in which case it is verified.
+func validateDocument(v *validator, field string, documentType DocumentType, number string) {
+	numberField := field + ".document_number"
+
+	switch documentType {
+	case DocumentTypeNit:
+		nit, checkDigit, hasCheckDigit := strings.Cut(number, "-")
+		if !isDigits(nit) || len(nit) < 6 || len(nit) > 10 || (hasCheckDigit && (len(checkDigit) != 1 || !isDigits(checkDigit))) {
+			v.add(numberField, "must have 6 to 10 digits, optionally followed by a dash and the check digit")
+			return
+		}
+		if hasCheckDigit {
+			expected := nitCheckDigit(nit)
+			v.check(checkDigit == strconv.Itoa(expected), numberField, "has an invalid check digit (expected %d)", expected)
+		}
+	case DocumentTypeCedula:
+		v.check(isDigits(number) && len(number) >= 4 && len(number) <= 10, numberField, "must have 4 to 10 digits")
+	case DocumentTypePep, DocumentTypePpt, DocumentTypeNuip, DocumentTypeRegistroCivil, DocumentTypeTarjetaIdentidad:
+		v.check(isDigits(number) && len(number) >= 4 && len(number) <= 15, numberField, "must have 4 to 15 digits")
+	case DocumentTypeCedulaExtranjeria, DocumentTypePasaporte, DocumentTypeDocumentoExtranjeria:
+		v.check(isAlphanumeric(number) && len(number) >= 4 && len(number) <= 15, numberField, "must have 4 to 15 letters or digits")
+	default:
+		v.add(field+".document_type", "%q is not a supported document type", documentType)
+	}
+}
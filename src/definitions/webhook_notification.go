@@ -0,0 +1,147 @@
+package definitions
+
+import "strings"
+
+// WebhookEventType represents the type of event that triggered a webhook notification.
+type WebhookEventType string
+
+const (
+	WebhookEventTypeSaleApproved WebhookEventType = "SALE_APPROVED" // The sale was approved.
+	WebhookEventTypeSaleRejected WebhookEventType = "SALE_REJECTED" // The sale was rejected.
+	WebhookEventTypeVoidApproved WebhookEventType = "VOID_APPROVED" // The void (cancellation) of a sale was approved.
+	WebhookEventTypeVoidRejected WebhookEventType = "VOID_REJECTED" // The void (cancellation) of a sale was rejected.
+)
+
+// IsKnown reports whether the event type is one of the types documented by Bold.
+// Notifications with unknown event types are still decoded, so new event types
+// do not break existing receivers.
+func (t WebhookEventType) IsKnown() bool {
+	switch t {
+	case WebhookEventTypeSaleApproved, WebhookEventTypeSaleRejected,
+		WebhookEventTypeVoidApproved, WebhookEventTypeVoidRejected:
+		return true
+	}
+	return false
+}
+
+// WebhookNotificationAmount contains the amounts of the payment that triggered the notification.
+type WebhookNotificationAmount struct {
+	// Currency is the currency of the payment.
+	Currency CurrencyType `json:"currency"`
+
+	// Total is the total amount of the payment, including taxes and tip.
+	Total float64 `json:"total"`
+
+	// Taxes is the list of taxes applied to the payment.
+	Taxes []Tax `json:"taxes,omitempty"`
+
+	// Tip is the tip amount included in the payment.
+	Tip float64 `json:"tip"`
+}
+
+// TotalMoney returns the total amount as an exact Money amount.
+func (a WebhookNotificationAmount) TotalMoney() Money {
+	return MoneyFromFloat(a.Total, a.Currency)
+}
+
+// TipMoney returns the tip amount as an exact Money amount.
+func (a WebhookNotificationAmount) TipMoney() Money {
+	return MoneyFromFloat(a.Tip, a.Currency)
+}
+
+// TaxesMoney returns the sum of the values of the taxes as an exact Money amount.
+func (a WebhookNotificationAmount) TaxesMoney() Money {
+	return sumTaxValues(a.Taxes, a.Currency)
+}
+
+// WebhookNotificationCard contains the details of the card used for the payment.
+type WebhookNotificationCard struct {
+	// CaptureMode is how the card was read (e.g., CHIP, CONTACTLESS).
+	CaptureMode string `json:"capture_mode"`
+
+	// Franchise is the franchise of the card (e.g., VISA, MASTERCARD).
+	Franchise string `json:"franchise"`
+
+	// CardholderName is the name of the cardholder.
+	CardholderName string `json:"cardholder_name"`
+
+	// TerminalID is the identifier of the terminal that processed the payment.
+	TerminalID string `json:"terminal_id"`
+}
+
+// WebhookNotificationMetadata contains the metadata sent by the merchant when creating the payment.
+type WebhookNotificationMetadata struct {
+	// Reference identifies the payment in the merchant's system. It is the
+	// Reference of the CreatePaymentForIntegrationsAPIRequest for payments
+	// created through the integrations API, or the payment link ID for payments
+	// made through a payment link.
+	Reference string `json:"reference"`
+}
+
+// PaymentLinkID returns the payment link ID when the notification belongs
+// to a payment made through a payment link.
+func (m WebhookNotificationMetadata) PaymentLinkID() (string, bool) {
+	if strings.HasPrefix(m.Reference, "LNK_") {
+		return m.Reference, true
+	}
+	return "", false
+}
+
+// WebhookNotificationData contains the details of the payment that triggered the notification.
+type WebhookNotificationData struct {
+	// PaymentID is the unique identifier of the payment.
+	PaymentID string `json:"payment_id"`
+
+	// MerchantID is the identifier of the merchant that received the payment.
+	MerchantID string `json:"merchant_id"`
+
+	// CreatedAt is the date when the payment was created.
+	CreatedAt string `json:"created_at"`
+
+	// Amount contains the amounts of the payment.
+	Amount WebhookNotificationAmount `json:"amount"`
+
+	// Card contains the details of the card used for the payment.
+	// It is nil when the payment was not made with a card.
+	Card *WebhookNotificationCard `json:"card,omitempty"`
+
+	// UserID is the identifier of the user that processed the payment.
+	UserID string `json:"user_id,omitempty"`
+
+	// PaymentMethod is the method used for the payment.
+	PaymentMethod PaymentMethod `json:"payment_method"`
+
+	// BoldCode is the code Bold assigns to the transaction.
+	BoldCode string `json:"bold_code,omitempty"`
+
+	// Metadata contains the metadata sent by the merchant when creating the payment.
+	Metadata WebhookNotificationMetadata `json:"metadata"`
+}
+
+// WebhookNotification represents a notification sent by Bold through webhooks.
+type WebhookNotification struct {
+	// ID is the unique identifier of the notification.
+	ID string `json:"id"`
+
+	// Type is the type of event that triggered the notification.
+	// Unknown event types are decoded as-is; use IsKnown to detect them.
+	Type WebhookEventType `json:"type"`
+
+	// Subject is the identifier of the resource related to the notification.
+	Subject string `json:"subject"`
+
+	// Source is the origin of the notification.
+	Source string `json:"source"`
+
+	// SpecVersion is the version of the notification specification.
+	SpecVersion string `json:"spec_version"`
+
+	// Time is the timestamp when the notification was generated, in Unix nanoseconds.
+	Time int64 `json:"time"`
+
+	// Data contains the details of the payment.
+	Data WebhookNotificationData `json:"data"`
+
+	// DataContentType is the content type of the data field.
+	DataContentType string `json:"datacontenttype"`
+}
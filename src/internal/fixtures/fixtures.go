@@ -0,0 +1,60 @@
+// Package fixtures contains the data shared by the fake implementations of the
+// Bold API (the boldtest server and the sdkfake client), so both behave the same.
+package fixtures
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/PChaparro/bold-co-sdk/src/definitions"
+)
+
+// PaymentMethods are the default payment methods for payment links, and their
+// limits in pesos.
+var PaymentMethods = definitions.PaymentMethodsMap{
+	definitions.PaymentMethodCreditCard:       {Min: 1000, Max: 20000000},
+	definitions.PaymentMethodPse:              {Min: 1000, Max: 10000000},
+	definitions.PaymentMethodBotonBancolombia: {Min: 1000, Max: 10000000},
+	definitions.PaymentMethodNequi:            {Min: 1000, Max: 2000000},
+}
+
+// IntegrationPaymentMethods are the default payment methods for the integrations API.
+var IntegrationPaymentMethods = []definitions.IntegrationPaymentMethod{
+	{Name: definitions.PaymentMethodPos, Enabled: true},
+	{Name: definitions.PaymentMethodNequi, Enabled: true},
+	{Name: definitions.PaymentMethodDaviplata, Enabled: true},
+	{Name: definitions.PaymentMethodPayByLink, Enabled: true},
+}
+
+// NewPaymentLink builds the payment link created by Bold for the request, with
+// the ACTIVE status. The sequence is the number of the link among the links
+// created by the fake, and determines its ID (e.g., LNK_000001).
+func NewPaymentLink(sequence int, req definitions.CreatePaymentLinkRequest) definitions.PaymentLinkDetails {
+	details := definitions.PaymentLinkDetails{
+		APIVersion:   1,
+		ID:           fmt.Sprintf("LNK_%06d", sequence),
+		Status:       definitions.PaymentLinkStatusActive,
+		CreationDate: time.Now().UnixNano(),
+		AmountType:   req.AmountType,
+		IsSandbox:    true,
+	}
+	if req.Amount != nil {
+		details.Total = req.Amount.TotalAmount
+		details.TipAmount = req.Amount.TipAmount
+		details.Taxes = req.Amount.Taxes
+		subtotal := req.Amount.TotalMoney().MinorUnits() - req.Amount.TipMoney().MinorUnits() - req.Amount.TaxesMoney().MinorUnits()
+		details.Subtotal = definitions.NewMoney(subtotal, definitions.CurrencyTypeCOP).Float64()
+	}
+	if req.Description != "" {
+		details.Description = &req.Description
+	}
+	if req.ExpirationDate != 0 {
+		details.ExpirationDate = &req.ExpirationDate
+	}
+	return details
+}
+
+// PaymentLinkURL returns the checkout URL of a payment link.
+func PaymentLinkURL(id string) string {
+	return "https://checkout.bold.co/payment/" + id
+}
@@ -1,6 +1,6 @@
-// Package http provides a singleton HTTP client for making API requests
-// to external services. It simplifies making HTTP requests by providing
-// methods for the most common HTTP operations (GET, POST).
+// Package http provides an HTTP client for making API requests to external
+// services. It simplifies making HTTP requests by providing methods for the
+// most common HTTP operations (GET, POST).
 package http
 
 import (
@@ -10,8 +10,9 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/http/httptrace"
 	"net/url"
-	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -29,8 +30,40 @@ type RequestOptions struct {
 	// QueryParams to include in the URL.
 	QueryParams map[string]string
 
-	// Timeout for this specific request.
+	// Timeout for this specific request, including all its attempts. It is applied
+	// through the request context, so the transport of the client is preserved.
 	Timeout time.Duration
+
+	// RetryPolicy to apply to this request. If nil, the request is attempted only once.
+	RetryPolicy *RetryPolicy
+
+	// BeforeAttempt is called before every attempt with its number, starting
+	// at 1 (e.g., to wait for a rate limiter). Returning an error aborts the request.
+	BeforeAttempt func(ctx context.Context, attempt int) error
+
+	// AfterAttempt is called after every attempt, before waiting for the next one.
+	AfterAttempt func(attempt Attempt)
+}
+
+// Attempt describes the result of an attempt to send a request.
+type Attempt struct {
+	// Number is the number of the attempt, starting at 1.
+	Number int
+
+	// StatusCode is the HTTP status code of the response (0 if the attempt failed).
+	StatusCode int
+
+	// Headers from the response (nil if the attempt failed).
+	Headers http.Header
+
+	// Duration is how long the attempt took.
+	Duration time.Duration
+
+	// Err is the error of the attempt, if it failed without a response.
+	Err error
+
+	// Retry reports whether the request is going to be attempted again.
+	Retry bool
 }
 
 // HTTPResponse represents the response from an HTTP .
@@ -43,32 +76,30 @@ type HTTPResponse struct {
 
 	// Headers from the response.
 	Headers http.Header
+
+	// Attempts is the number of attempts performed to get the response.
+	Attempts int
 }
 
-// Client is a singleton HTTP client for making requests.
+// Client is an HTTP client for making requests.
 type Client struct {
 	httpClient *http.Client
 }
 
-var (
-	instance *Client
-	once     sync.Once
-)
-
-// GetClient returns the singleton instance of the HTTP client.
-func GetClient() *Client {
-	once.Do(func() {
-		instance = &Client{
-			httpClient: &http.Client{
-				Timeout: 30 * time.Second, // Default timeout
-			},
-		}
-	})
-	return instance
+// NewClient creates a new HTTP client that sends the requests using the
+// given *http.Client.
+func NewClient(httpClient *http.Client) *Client {
+	return &Client{
+		httpClient: httpClient,
+	}
 }
 
 // GET performs an HTTP GET request.
 func (c *Client) GET(ctx context.Context, options RequestOptions) (*HTTPResponse, error) {
+	// Set timeout for this specific request if provided
+	ctx, cancel := withTimeout(ctx, options.Timeout)
+	defer cancel()
+
 	// Build the URL with query parameters
 	reqURL, err := c.buildURL(options.URL, options.QueryParams)
 	if err != nil {
@@ -76,37 +107,37 @@ func (c *Client) GET(ctx context.Context, options RequestOptions) (*HTTPResponse
 	}
 
 	// Create request
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
-	if err != nil {
-		return nil, fmt.Errorf("error creating request: %w", err)
-	}
+	newRequest := func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error creating request: %w", err)
+		}
 
-	// Add headers
-	c.addHeaders(req, options.Headers)
+		// Add headers
+		c.addHeaders(req, options.Headers)
 
-	// Set timeout for this specific request if provided
-	client := c.httpClient
-	if options.Timeout > 0 {
-		client = &http.Client{
-			Timeout: options.Timeout,
-		}
+		return req, nil
 	}
 
 	// Execute request
-	return c.doRequest(client, req)
+	return c.doRequestWithRetries(ctx, newRequest, options)
 }
 
 // POST performs an HTTP POST request.
 func (c *Client) POST(ctx context.Context, options RequestOptions) (*HTTPResponse, error) {
-	var reqBody io.Reader
+	// Set timeout for this specific request if provided
+	ctx, cancel := withTimeout(ctx, options.Timeout)
+	defer cancel()
+
+	var jsonData []byte
 
 	// Process body if provided
 	if options.Body != nil {
-		jsonData, err := json.Marshal(options.Body)
+		var err error
+		jsonData, err = json.Marshal(options.Body)
 		if err != nil {
 			return nil, fmt.Errorf("error marshalling request body: %w", err)
 		}
-		reqBody = bytes.NewBuffer(jsonData)
 	}
 
 	// Build the URL with query parameters
@@ -115,30 +146,31 @@ func (c *Client) POST(ctx context.Context, options RequestOptions) (*HTTPRespons
 		return nil, fmt.Errorf("error building URL: %w", err)
 	}
 
-	// Create request
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, reqURL, reqBody)
-	if err != nil {
-		return nil, fmt.Errorf("error creating request: %w", err)
-	}
+	// Create request. The body is rebuilt on every attempt so it can be retried.
+	newRequest := func() (*http.Request, error) {
+		var reqBody io.Reader
+		if jsonData != nil {
+			reqBody = bytes.NewReader(jsonData)
+		}
 
-	// Add headers
-	c.addHeaders(req, options.Headers)
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, reqURL, reqBody)
+		if err != nil {
+			return nil, fmt.Errorf("error creating request: %w", err)
+		}
 
-	// Set content type if not explicitly provided
-	if reqBody != nil && req.Header.Get("Content-Type") == "" {
-		req.Header.Set("Content-Type", "application/json")
-	}
+		// Add headers
+		c.addHeaders(req, options.Headers)
 
-	// Set timeout for this specific request if provided
-	client := c.httpClient
-	if options.Timeout > 0 {
-		client = &http.Client{
-			Timeout: options.Timeout,
+		// Set content type if not explicitly provided
+		if reqBody != nil && req.Header.Get("Content-Type") == "" {
+			req.Header.Set("Content-Type", "application/json")
 		}
+
+		return req, nil
 	}
 
 	// Execute request
-	return c.doRequest(client, req)
+	return c.doRequestWithRetries(ctx, newRequest, options)
 }
 
 // buildURL constructs the full URL with query parameters.
@@ -161,6 +193,15 @@ func (c *Client) buildURL(baseURL string, params map[string]string) (string, err
 	return parsedURL.String(), nil
 }
 
+// withTimeout returns a copy of the context with the given timeout applied.
+// If the timeout is not positive, the context is returned unchanged.
+func withTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
 // addHeaders adds the specified headers to the request.
 func (c *Client) addHeaders(req *http.Request, headers map[string]string) {
 	for key, value := range headers {
@@ -168,9 +209,96 @@ func (c *Client) addHeaders(req *http.Request, headers map[string]string) {
 	}
 }
 
+// doRequestWithRetries executes the HTTP request, retrying it according to
+// the given policy, and returns the last response obtained.
+func (c *Client) doRequestWithRetries(
+	ctx context.Context,
+	newRequest func() (*http.Request, error),
+	options RequestOptions,
+) (*HTTPResponse, error) {
+	policy := options.RetryPolicy
+	maxAttempts := policy.maxAttempts()
+	var last *HTTPResponse
+
+	for attempt := 1; ; attempt++ {
+		if options.BeforeAttempt != nil {
+			if err := options.BeforeAttempt(ctx, attempt); err != nil {
+				// Return the last response, if any, like when the context is done while waiting
+				if last != nil {
+					return last, nil
+				}
+				return nil, &RetryError{Attempts: attempt - 1, Err: err}
+			}
+		}
+
+		// Do not send the attempt if the context is already done
+		if err := ctx.Err(); err != nil {
+			if last != nil {
+				return last, nil
+			}
+			return nil, &RetryError{Attempts: attempt - 1, Err: err}
+		}
+
+		req, err := newRequest()
+		if err != nil {
+			return nil, &RetryError{Attempts: attempt - 1, Err: err}
+		}
+
+		// Track whether the request reached the server to know if it is safe to retry it.
+		// The trace callbacks run on the transport goroutines, so the flag is atomic.
+		var wroteRequest atomic.Bool
+		trace := &httptrace.ClientTrace{
+			WroteRequest: func(httptrace.WroteRequestInfo) { wroteRequest.Store(true) },
+		}
+		req = req.WithContext(httptrace.WithClientTrace(req.Context(), trace))
+
+		start := time.Now()
+		response, err := c.doRequest(req)
+		if err != nil {
+			retry := attempt < maxAttempts && policy.shouldRetryError(ctx, req.Method, err, wroteRequest.Load())
+			options.afterAttempt(Attempt{Number: attempt, Duration: time.Since(start), Err: err, Retry: retry})
+			if !retry {
+				return nil, &RetryError{Attempts: attempt, Err: err}
+			}
+
+			if err := sleep(ctx, policy.delay(attempt, nil)); err != nil {
+				return nil, &RetryError{Attempts: attempt, Err: err}
+			}
+			last = nil
+			continue
+		}
+
+		response.Attempts = attempt
+		retry := attempt < maxAttempts && policy.shouldRetryStatus(req.Method, response.StatusCode)
+		options.afterAttempt(Attempt{
+			Number:     attempt,
+			StatusCode: response.StatusCode,
+			Headers:    response.Headers,
+			Duration:   time.Since(start),
+			Retry:      retry,
+		})
+		if !retry {
+			return response, nil
+		}
+
+		// Return the last response if the context is done while waiting
+		if err := sleep(ctx, policy.delay(attempt, response.Headers)); err != nil {
+			return response, nil
+		}
+		last = response
+	}
+}
+
+// afterAttempt calls the AfterAttempt hook, if any.
+func (o RequestOptions) afterAttempt(attempt Attempt) {
+	if o.AfterAttempt != nil {
+		o.AfterAttempt(attempt)
+	}
+}
+
 // doRequest executes the HTTP request and processes the response.
-func (c *Client) doRequest(client *http.Client, req *http.Request) (*HTTPResponse, error) {
-	resp, err := client.Do(req)
+func (c *Client) doRequest(req *http.Request) (*HTTPResponse, error) {
+	resp, err := c.httpClient.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error executing request: %w", err)
 	}
@@ -0,0 +1,171 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy contains the options to retry failed HTTP requests.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts, including the first one.
+	// Values lower than 2 disable retries.
+	MaxAttempts int
+
+	// BaseDelay is the delay before the first retry. It doubles on every
+	// subsequent retry.
+	BaseDelay time.Duration
+
+	// MaxDelay caps the delay between two attempts, including the delays
+	// requested by the server through the Retry-After header.
+	MaxDelay time.Duration
+
+	// Jitter is the fraction (between 0 and 1) of the delay that is randomized
+	// to avoid synchronized retries from several clients.
+	Jitter float64
+
+	// RetryableStatusCodes are the response status codes that trigger a retry.
+	RetryableStatusCodes []int
+
+	// RetryNonIdempotent allows retrying non-idempotent requests (POST) after
+	// they may have reached the server. When disabled, POST requests are only
+	// retried when the request was never sent or when the server explicitly
+	// rejected it without processing it (HTTP 429).
+	RetryNonIdempotent bool
+}
+
+// RetryError is returned when a request fails without getting a response
+// from the server. It carries the number of attempts performed.
+type RetryError struct {
+	// Attempts is the number of attempts performed before giving up.
+	Attempts int
+
+	// Err is the error of the last attempt.
+	Err error
+}
+
+// Error implements the error interface.
+func (e *RetryError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the error of the last attempt.
+func (e *RetryError) Unwrap() error {
+	return e.Err
+}
+
+// maxAttempts returns the maximum number of attempts allowed by the policy.
+func (p *RetryPolicy) maxAttempts() int {
+	if p == nil || p.MaxAttempts < 1 {
+		return 1
+	}
+	return p.MaxAttempts
+}
+
+// shouldRetryStatus reports whether a response with the given status code
+// should be retried for the given HTTP method.
+func (p *RetryPolicy) shouldRetryStatus(method string, statusCode int) bool {
+	if !slices.Contains(p.RetryableStatusCodes, statusCode) {
+		return false
+	}
+
+	// Rate limited requests were not processed, so they are always safe to retry
+	if statusCode == http.StatusTooManyRequests {
+		return true
+	}
+
+	return isIdempotent(method) || p.RetryNonIdempotent
+}
+
+// shouldRetryError reports whether a request that failed with the given
+// error should be retried for the given HTTP method.
+func (p *RetryPolicy) shouldRetryError(ctx context.Context, method string, err error, wroteRequest bool) bool {
+	// Do not retry if the caller canceled the request or its deadline expired
+	if ctx.Err() != nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	// Requests that never reached the server are always safe to retry
+	if !wroteRequest {
+		return true
+	}
+
+	return isIdempotent(method) || p.RetryNonIdempotent
+}
+
+// delay returns the time to wait before the given retry (starting at 1).
+// If the response contains a Retry-After header, it takes precedence over
+// the exponential backoff.
+func (p *RetryPolicy) delay(retry int, headers http.Header) time.Duration {
+	delay := p.BaseDelay << (retry - 1)
+	if delay <= 0 && p.BaseDelay > 0 {
+		// Overflow due to the shift
+		delay = p.MaxDelay
+	}
+
+	if p.Jitter > 0 && delay > 0 {
+		jitter := min(p.Jitter, 1)
+		randomized := float64(delay) * jitter * rand.Float64()
+		delay = delay - time.Duration(float64(delay)*jitter) + time.Duration(2*randomized)
+	}
+
+	if retryAfter, ok := ParseRetryAfter(headers); ok {
+		delay = retryAfter
+	}
+
+	if p.MaxDelay > 0 && delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+
+	return delay
+}
+
+// isIdempotent reports whether the given HTTP method is idempotent.
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// ParseRetryAfter parses the Retry-After header, which can be expressed
+// either in seconds or as an HTTP date.
+func ParseRetryAfter(headers http.Header) (time.Duration, bool) {
+	value := headers.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0), true
+	}
+
+	return 0, false
+}
+
+// sleep waits for the given duration or until the context is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return fmt.Errorf("retry canceled: %w", ctx.Err())
+	case <-timer.C:
+		return nil
+	}
+}
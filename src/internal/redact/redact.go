@@ -0,0 +1,115 @@
+// Package redact replaces the secrets and the personal data of HTTP headers
+// and JSON bodies before they are logged or stored.
+package redact
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// Placeholder replaces the redacted values.
+const Placeholder = "REDACTED"
+
+// Fields are the JSON fields containing the contact and identification data
+// of payers and sellers.
+var Fields = []string{
+	"email",
+	"payer_email",
+	"user_email",
+	"phone_number",
+	"document_number",
+	"cardholder_name",
+}
+
+// Headers are the headers containing secrets. The Authorization header of the
+// requests to the Bold API contains the API key.
+var Headers = []string{
+	"Authorization",
+	"Cookie",
+	"Set-Cookie",
+}
+
+// Set builds a set with the lowercase form of the given names.
+func Set(names ...[]string) map[string]bool {
+	set := make(map[string]bool)
+	for _, list := range names {
+		for _, name := range list {
+			set[strings.ToLower(name)] = true
+		}
+	}
+	return set
+}
+
+// Header returns a copy of the header with the values of the given headers
+// replaced. It returns nil for empty headers.
+func Header(header http.Header, names []string) http.Header {
+	if len(header) == 0 {
+		return nil
+	}
+
+	redacted := header.Clone()
+	for _, name := range names {
+		if redacted.Get(name) != "" {
+			redacted.Set(name, Placeholder)
+		}
+	}
+	return redacted
+}
+
+// JSON replaces the given fields (see Set) of a JSON body, at any depth.
+// Bodies that are not JSON are returned as-is.
+func JSON(body []byte, fields map[string]bool) string {
+	value, ok := DecodeJSON(body)
+	if !ok {
+		return string(body)
+	}
+
+	encoded, err := json.Marshal(Value(value, fields, nil))
+	if err != nil {
+		return string(body)
+	}
+	return string(encoded)
+}
+
+// Value replaces the given fields of a decoded JSON value and deletes the
+// removed ones, at any depth. Maps and slices are modified in place.
+func Value(value any, fields map[string]bool, removed map[string]bool) any {
+	switch value := value.(type) {
+	case map[string]any:
+		for key, field := range value {
+			switch {
+			case removed[strings.ToLower(key)]:
+				delete(value, key)
+			case fields[strings.ToLower(key)]:
+				if field != nil {
+					value[key] = Placeholder
+				}
+			default:
+				value[key] = Value(field, fields, removed)
+			}
+		}
+	case []any:
+		for i, item := range value {
+			value[i] = Value(item, fields, removed)
+		}
+	}
+	return value
+}
+
+// DecodeJSON decodes a JSON body, keeping the numbers as they were written.
+func DecodeJSON(body []byte) (any, bool) {
+	if len(bytes.TrimSpace(body)) == 0 {
+		return nil, false
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return nil, false
+	}
+	return value, true
+}
@@ -0,0 +1,33 @@
+package sdk
+
+import (
+	"context"
+
+	"github.com/PChaparro/bold-co-sdk/src/definitions"
+)
+
+// BoldAPI contains the operations of the Bold API. It is implemented by
+// *BoldClient, so services can depend on this interface and be tested with a
+// fake implementation (e.g., the one in the sdkfake package).
+type BoldAPI interface {
+	// CreatePaymentLink creates a payment link.
+	CreatePaymentLink(ctx context.Context, req definitions.CreatePaymentLinkRequest, opts ...RequestOption) (*definitions.CreatePaymentLinkResponse, error)
+
+	// GetPaymentLinkData retrieves the data of a payment link.
+	GetPaymentLinkData(ctx context.Context, paymentLinkId string, opts ...RequestOption) (*definitions.GetPaymentLinkDataResponse, error)
+
+	// GetPaymentMethodsForPaymentLink retrieves the payment methods available for payment links.
+	GetPaymentMethodsForPaymentLink(ctx context.Context, opts ...RequestOption) (*definitions.GetPaymentMethodsForPaymentLinkResponse, error)
+
+	// GetPaymentMethodsForIntegrationsAPI retrieves the payment methods available for the integrations API.
+	GetPaymentMethodsForIntegrationsAPI(ctx context.Context, opts ...RequestOption) (*definitions.GetPaymentMethodsForIntegrationsAPIResponse, error)
+
+	// GetBindedTerminalsForIntegrationsAPI retrieves the terminals binded to the integration.
+	GetBindedTerminalsForIntegrationsAPI(ctx context.Context, opts ...RequestOption) (*definitions.GetBindedTerminalsForIntegrationsAPIResponse, error)
+
+	// CreatePaymentForIntegrationsAPI creates a payment using the integrations API.
+	CreatePaymentForIntegrationsAPI(ctx context.Context, req definitions.CreatePaymentForIntegrationsAPIRequest, opts ...RequestOption) (*definitions.CreatePaymentForIntegrationsAPIResponse, error)
+}
+
+// Verify that BoldClient implements the BoldAPI interface.
+var _ BoldAPI = (*BoldClient)(nil)
@@ -2,9 +2,18 @@
 package sdk
 
 import (
+	"log/slog"
+	"net/http"
+	"time"
+
 	httpClient "github.com/PChaparro/bold-co-sdk/src/internal/http"
+	"go.opentelemetry.io/otel/trace"
 )
 
+// DefaultTimeout is the timeout applied to the requests when neither
+// ClientConfig.Timeout nor ClientConfig.HTTPClient are provided.
+const DefaultTimeout = 30 * time.Second
+
 // ClientConfig contains the configuration options for the BoldClient.
 type ClientConfig struct {
 	// ApiKey is the authentication key required to access Bold API.
@@ -13,12 +22,74 @@ type ClientConfig struct {
 	// BaseURL is the base URL for the Bold API.
 	// If not provided, it defaults to "https://integrations.api.bold.co".
 	BaseURL string
+
+	// RetryPolicy defines how failed requests are retried.
+	// If not provided, requests are attempted only once. See DefaultRetryPolicy.
+	RetryPolicy *RetryPolicy
+
+	// HTTPClient is the HTTP client used to send the requests (e.g., to configure
+	// proxies, TLS or connection pooling). It is not modified by the SDK.
+	// If not provided, each BoldClient creates its own HTTP client.
+	HTTPClient *http.Client
+
+	// Transport is the round tripper used to send the requests.
+	// If provided, it replaces the transport of HTTPClient.
+	Transport http.RoundTripper
+
+	// Timeout is the default timeout for the requests.
+	// If provided, it replaces the timeout of HTTPClient. Otherwise, it defaults
+	// to DefaultTimeout, unless HTTPClient is provided.
+	Timeout time.Duration
+
+	// Middlewares wrap every request sent to the Bold API. They run in the
+	// given order: the first middleware is the first to see the request and
+	// the last to see the response.
+	Middlewares []Middleware
+
+	// DisableValidation skips the client-side validation of the requests
+	// (e.g., CreatePaymentLinkRequest.Validate), sending them as-is to the Bold API.
+	DisableValidation bool
+
+	// Preflight checks the payment methods of the payment links against their
+	// limits before creating them. If not provided, the limits are not checked.
+	Preflight *PreflightConfig
+
+	// Cache caches the responses of the endpoints returning near-static data.
+	// If not provided, every call is sent to the Bold API.
+	Cache *CacheConfig
+
+	// Logger logs the requests sent to the Bold API, with their action,
+	// endpoint, status code, latency and attempts. The API key and the personal
+	// data of the payers are redacted. If not provided, nothing is logged.
+	Logger *slog.Logger
+
+	// LogLevels are the levels of the logged messages.
+	// If not provided, DefaultLogLevels are used.
+	LogLevels *LogLevels
+
+	// TracerProvider creates a client span for every operation, named after
+	// its action, as a child of the span in the context of the call.
+	// If not provided, no spans are created.
+	TracerProvider trace.TracerProvider
+
+	// Metrics receives the measurements of the client (e.g., request counts,
+	// latencies, retries and cache lookups). If not provided, nothing is measured.
+	Metrics Metrics
+
+	// RateLimit limits the rate of the requests sent to the Bold API, globally
+	// and per endpoint group. If not provided, the requests are not limited.
+	RateLimit *RateLimitConfig
 }
 
 // BoldClient is a client for interacting with the Bold API.
 type BoldClient struct {
-	config     ClientConfig
-	httpClient *httpClient.Client
+	config      ClientConfig
+	httpClient  *httpClient.Client
+	retryPolicy *httpClient.RetryPolicy
+	limits      *limitsCache
+	cache       *responseCache
+	tracer      trace.Tracer
+	limiter     *rateLimiter
 }
 
 // NewClient creates a new instance of the BoldClient.
@@ -29,11 +100,40 @@ func NewClient(config ClientConfig) *BoldClient {
 		config.BaseURL = "https://integrations.api.bold.co"
 	}
 
-	// Get the HTTP client instance
-	client := httpClient.GetClient()
+	sender := newHTTPClient(config)
+	client := &BoldClient{
+		config:      config,
+		httpClient:  httpClient.NewClient(sender),
+		retryPolicy: config.RetryPolicy.toInternal(),
+		limits:      &limitsCache{timeout: sender.Timeout},
+		cache:       newResponseCache(config, sender.Timeout),
+		limiter:     newRateLimiter(config),
+	}
+	if config.TracerProvider != nil {
+		client.tracer = config.TracerProvider.Tracer(TracerName)
+	}
+
+	return client
+}
+
+// newHTTPClient builds the HTTP client owned by a BoldClient. The client
+// provided in the configuration is copied, so it is never modified.
+func newHTTPClient(config ClientConfig) *http.Client {
+	var client http.Client
+	if config.HTTPClient != nil {
+		client = *config.HTTPClient
+	} else {
+		client.Timeout = DefaultTimeout
+		client.Transport = http.DefaultTransport.(*http.Transport).Clone()
+	}
 
-	return &BoldClient{
-		config:     config,
-		httpClient: client,
+	if config.Transport != nil {
+		client.Transport = config.Transport
 	}
+
+	if config.Timeout > 0 {
+		client.Timeout = config.Timeout
+	}
+
+	return &client
 }
@@ -0,0 +1,101 @@
+package sdk
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/PChaparro/bold-co-sdk/src/boldtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// roundTripperFunc allows using a function as an http.RoundTripper.
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// newSandboxClient returns a client for the Bold sandbox when the BOLD_API_KEY
+// environment variable is set. Otherwise, it returns a client for a test server
+// that behaves like a sandbox account without payment terminals, so the
+// endpoint tests also run without credentials.
+func newSandboxClient(t *testing.T) *BoldClient {
+	t.Helper()
+
+	if apiKey := os.Getenv("BOLD_API_KEY"); apiKey != "" {
+		return NewClient(ClientConfig{ApiKey: apiKey})
+	}
+
+	server := boldtest.NewServer(boldtest.Config{})
+	t.Cleanup(server.Close)
+	return NewClient(ClientConfig{ApiKey: boldtest.DefaultAPIKey, BaseURL: server.URL})
+}
+
+func TestNewClient(t *testing.T) {
+	t.Run("default HTTP client", func(t *testing.T) {
+		client := NewClient(ClientConfig{ApiKey: "test-api-key"})
+
+		httpClient := newHTTPClient(client.config)
+		assert.Equal(t, DefaultTimeout, httpClient.Timeout)
+		assert.NotNil(t, httpClient.Transport)
+		assert.NotSame(t, http.DefaultTransport, httpClient.Transport)
+		assert.Equal(t, "https://integrations.api.bold.co", client.config.BaseURL)
+	})
+
+	t.Run("custom HTTP client is not modified", func(t *testing.T) {
+		custom := &http.Client{Timeout: 5 * time.Second}
+
+		httpClient := newHTTPClient(ClientConfig{
+			HTTPClient: custom,
+			Timeout:    10 * time.Second,
+		})
+
+		assert.Equal(t, 10*time.Second, httpClient.Timeout)
+		assert.Equal(t, 5*time.Second, custom.Timeout)
+		assert.NotSame(t, custom, httpClient)
+	})
+
+	t.Run("custom HTTP client is used", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "x-api-key test-api-key", r.Header.Get("Authorization"))
+			_, _ = w.Write([]byte(`{"payload":{"payment_methods":[{"name":"POS","enabled":true}]}}`))
+		}))
+		defer server.Close()
+
+		client := NewClient(ClientConfig{
+			ApiKey:     "test-api-key",
+			BaseURL:    server.URL,
+			HTTPClient: server.Client(),
+		})
+
+		response, err := client.GetPaymentMethodsForIntegrationsAPI(context.Background())
+		require.NoError(t, err)
+		require.NotNil(t, response.Payload.PaymentMethods)
+		assert.Len(t, *response.Payload.PaymentMethods, 1)
+	})
+
+	t.Run("clients with different transports coexist", func(t *testing.T) {
+		newTransport := func(status int) http.RoundTripper {
+			return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				recorder := httptest.NewRecorder()
+				recorder.WriteHeader(status)
+				_, _ = recorder.WriteString(`{"payload":{}}`)
+				return recorder.Result(), nil
+			})
+		}
+
+		okClient := NewClient(ClientConfig{ApiKey: "test-api-key", Transport: newTransport(http.StatusOK)})
+		notFoundClient := NewClient(ClientConfig{ApiKey: "test-api-key", Transport: newTransport(http.StatusNotFound)})
+
+		_, err := okClient.GetBindedTerminalsForIntegrationsAPI(context.Background())
+		require.NoError(t, err)
+
+		_, err = notFoundClient.GetBindedTerminalsForIntegrationsAPI(context.Background())
+		require.ErrorIs(t, err, ErrNotFound)
+	})
+}
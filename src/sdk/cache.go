@@ -0,0 +1,243 @@
+package sdk
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// DefaultCacheTTL is how long the cached responses are kept when
+// CacheConfig.TTL is not provided.
+const DefaultCacheTTL = 5 * time.Minute
+
+// Cache stores the raw responses of the cacheable endpoints. Implementations
+// must be safe for concurrent use. Errors are not fatal: a failed Get is
+// treated as a miss and a failed Set only prevents caching the response.
+type Cache interface {
+	// Get returns the value stored for the key, and whether it was found.
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+
+	// Set stores the value for the key during the given TTL.
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+
+	// Delete removes the value stored for the key, if any.
+	Delete(ctx context.Context, key string) error
+}
+
+// CacheConfig contains the options to cache the responses of the endpoints
+// returning near-static data: GetPaymentMethodsForPaymentLink,
+// GetPaymentMethodsForIntegrationsAPI and GetBindedTerminalsForIntegrationsAPI.
+// Concurrent misses of the same endpoint are de-duplicated into a single request,
+// limited by the timeout of the client: the context and WithTimeout of each call
+// only limit how long that call waits for it.
+type CacheConfig struct {
+	// Cache is where the responses are stored.
+	// If not provided, they are stored in memory (see NewMemoryCache).
+	Cache Cache
+
+	// TTL is how long the responses are cached.
+	// If not provided, it defaults to DefaultCacheTTL.
+	TTL time.Duration
+}
+
+// CacheEntry identifies the cached response of an endpoint.
+type CacheEntry string
+
+const (
+	CacheEntryPaymentMethodsForPaymentLink      CacheEntry = "payment-methods-for-payment-link"      // GetPaymentMethodsForPaymentLink.
+	CacheEntryPaymentMethodsForIntegrationsAPI  CacheEntry = "payment-methods-for-integrations-api"  // GetPaymentMethodsForIntegrationsAPI.
+	CacheEntryBindedTerminalsForIntegrationsAPI CacheEntry = "binded-terminals-for-integrations-api" // GetBindedTerminalsForIntegrationsAPI.
+)
+
+// cacheEntries are all the cacheable entries.
+var cacheEntries = []CacheEntry{
+	CacheEntryPaymentMethodsForPaymentLink,
+	CacheEntryPaymentMethodsForIntegrationsAPI,
+	CacheEntryBindedTerminalsForIntegrationsAPI,
+}
+
+// InvalidateCache removes the given entries from the cache, so the next calls
+// fetch them again from the Bold API. If no entries are given, every entry is removed.
+// It does nothing if the cache is not configured.
+func (client *BoldClient) InvalidateCache(ctx context.Context, entries ...CacheEntry) error {
+	if client.cache == nil {
+		return nil
+	}
+	if len(entries) == 0 {
+		entries = cacheEntries
+	}
+
+	for _, entry := range entries {
+		if err := client.cache.store.Delete(ctx, client.cache.key(entry)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// responseCache caches the responses of the cacheable endpoints of a client.
+type responseCache struct {
+	store   Cache
+	ttl     time.Duration
+	timeout time.Duration // Timeout of the shared requests (0 for none).
+	prefix  string
+	flight  flightGroup[*Response]
+}
+
+// newResponseCache builds the response cache of a client, or returns nil if it
+// is not configured. The timeout is the timeout of the HTTP client.
+func newResponseCache(config ClientConfig, timeout time.Duration) *responseCache {
+	if config.Cache == nil {
+		return nil
+	}
+
+	cache := &responseCache{store: config.Cache.Cache, ttl: config.Cache.TTL, timeout: timeout}
+	if cache.store == nil {
+		cache.store = NewMemoryCache()
+	}
+	if cache.ttl <= 0 {
+		cache.ttl = DefaultCacheTTL
+	}
+
+	// Clients with different credentials may share the same store
+	hash := sha256.Sum256([]byte(config.BaseURL + "\n" + config.ApiKey))
+	cache.prefix = "bold-co-sdk:" + hex.EncodeToString(hash[:8]) + ":"
+
+	return cache
+}
+
+// key returns the key of the entry in the store.
+func (c *responseCache) key(entry CacheEntry) string {
+	return c.prefix + string(entry)
+}
+
+// do returns the cached response of the entry, calling the handler on misses.
+// It also reports whether the response was served from the cache.
+// Only successful responses are cached.
+//
+// The handler is shared by the concurrent misses of the entry, so it runs
+// with a context that is not cancelled when the caller that started it gives
+// up, limited by the timeout of the client instead.
+func (c *responseCache) do(ctx context.Context, entry CacheEntry, bypass bool, handler func(ctx context.Context) (*Response, error)) (*Response, bool, error) {
+	key := c.key(entry)
+
+	if !bypass {
+		if body, ok, err := c.store.Get(ctx, key); err == nil && ok {
+			return &Response{StatusCode: http.StatusOK, Body: body, Headers: http.Header{}}, true, nil
+		}
+	}
+
+	response, err := c.flight.do(ctx, key, func() (*Response, error) {
+		ctx, cancel := withTimeout(context.WithoutCancel(ctx), c.timeout)
+		defer cancel()
+
+		response, err := handler(ctx)
+		if err == nil && response != nil && response.StatusCode >= 200 && response.StatusCode < 300 {
+			_ = c.store.Set(ctx, key, response.Body, c.ttl)
+		}
+		return response, err
+	})
+	return response, false, err
+}
+
+// flightGroup de-duplicates concurrent calls with the same key.
+type flightGroup[T any] struct {
+	mu    sync.Mutex
+	calls map[string]*flightCall[T]
+}
+
+// flightCall is an in-flight call of a flightGroup.
+type flightCall[T any] struct {
+	done  chan struct{}
+	value T
+	err   error
+}
+
+// do waits for the result of the in-flight call with the same key, starting
+// it with fn if there is none, or until the context is done. The call runs in
+// its own goroutine, so it is not interrupted when its callers give up.
+func (g *flightGroup[T]) do(ctx context.Context, key string, fn func() (T, error)) (T, error) {
+	g.mu.Lock()
+	call, ok := g.calls[key]
+	if !ok {
+		if g.calls == nil {
+			g.calls = make(map[string]*flightCall[T])
+		}
+		call = &flightCall[T]{done: make(chan struct{})}
+		g.calls[key] = call
+
+		go func() {
+			defer func() {
+				g.mu.Lock()
+				delete(g.calls, key)
+				g.mu.Unlock()
+				close(call.done)
+			}()
+
+			call.value, call.err = fn()
+		}()
+	}
+	g.mu.Unlock()
+
+	select {
+	case <-call.done:
+		return call.value, call.err
+	case <-ctx.Done():
+		var zero T
+		return zero, ctx.Err()
+	}
+}
+
+// MemoryCache is an in-memory Cache. Expired entries are removed when accessed.
+type MemoryCache struct {
+	mu      sync.Mutex
+	entries map[string]memoryCacheEntry
+}
+
+// memoryCacheEntry is a value stored in a MemoryCache.
+type memoryCacheEntry struct {
+	value     []byte
+	expiresAt time.Time
+}
+
+// NewMemoryCache creates an empty in-memory cache.
+func NewMemoryCache() *MemoryCache {
+	return &MemoryCache{entries: make(map[string]memoryCacheEntry)}
+}
+
+// Get implements the Cache interface.
+func (c *MemoryCache) Get(_ context.Context, key string) ([]byte, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil, false, nil
+	}
+	if time.Now().After(entry.expiresAt) {
+		delete(c.entries, key)
+		return nil, false, nil
+	}
+	return entry.value, true, nil
+}
+
+// Set implements the Cache interface.
+func (c *MemoryCache) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries[key] = memoryCacheEntry{value: value, expiresAt: time.Now().Add(ttl)}
+	return nil
+}
+
+// Delete implements the Cache interface.
+func (c *MemoryCache) Delete(_ context.Context, key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.entries, key)
+	return nil
+}
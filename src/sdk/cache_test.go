@@ -0,0 +1,175 @@
+package sdk
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/PChaparro/bold-co-sdk/src/definitions"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCache(t *testing.T) {
+	var calls atomic.Int32
+	var fail atomic.Bool
+	release := make(chan struct{})
+	close(release)
+	var gate atomic.Pointer[chan struct{}]
+	gate.Store(&release)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		<-*gate.Load()
+		if fail.Load() {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		_, _ = w.Write([]byte(`{"payload":{"payment_methods":{"PSE":{"min":1000,"max":5000000}}},"errors":[]}`))
+	}))
+	defer server.Close()
+
+	newClient := func(cache Cache) *BoldClient {
+		return NewClient(ClientConfig{
+			ApiKey:  "test-api-key",
+			BaseURL: server.URL,
+			Cache:   &CacheConfig{Cache: cache, TTL: time.Minute},
+		})
+	}
+
+	t.Run("responses are cached", func(t *testing.T) {
+		calls.Store(0)
+		client := newClient(nil)
+
+		for range 3 {
+			response, err := client.GetPaymentMethodsForPaymentLink(context.Background())
+			require.NoError(t, err)
+			assert.Contains(t, response.Payload.PaymentMethods, definitions.PaymentMethodPse)
+		}
+		assert.Equal(t, int32(1), calls.Load())
+
+		// Other endpoints are cached separately
+		_, err := client.GetBindedTerminalsForIntegrationsAPI(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, int32(2), calls.Load())
+	})
+
+	t.Run("concurrent misses are de-duplicated", func(t *testing.T) {
+		calls.Store(0)
+		client := newClient(nil)
+
+		blocked := make(chan struct{})
+		gate.Store(&blocked)
+
+		var wg sync.WaitGroup
+		for range 10 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := client.GetBindedTerminalsForIntegrationsAPI(context.Background())
+				assert.NoError(t, err)
+			}()
+		}
+
+		// Let the goroutines join the in-flight request before releasing it
+		time.Sleep(50 * time.Millisecond)
+		close(blocked)
+		wg.Wait()
+		gate.Store(&release)
+
+		assert.Equal(t, int32(1), calls.Load())
+	})
+
+	t.Run("the shared request outlives the caller that started it", func(t *testing.T) {
+		calls.Store(0)
+		client := newClient(nil)
+
+		blocked := make(chan struct{})
+		gate.Store(&blocked)
+		defer gate.Store(&release)
+
+		leaderCtx, cancel := context.WithCancel(context.Background())
+		leaderErr := make(chan error, 1)
+		go func() {
+			_, err := client.GetPaymentMethodsForPaymentLink(leaderCtx)
+			leaderErr <- err
+		}()
+
+		// Wait for the request of the leader to reach the server
+		require.Eventually(t, func() bool { return calls.Load() == 1 }, time.Second, time.Millisecond)
+
+		waiterErr := make(chan error, 1)
+		go func() {
+			_, err := client.GetPaymentMethodsForPaymentLink(context.Background())
+			waiterErr <- err
+		}()
+
+		// The waiter with a per-call timeout gives up without affecting the others
+		_, err := client.GetPaymentMethodsForPaymentLink(context.Background(), WithTimeout(10*time.Millisecond))
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+
+		cancel()
+		require.ErrorIs(t, <-leaderErr, context.Canceled)
+
+		close(blocked)
+		require.NoError(t, <-waiterErr)
+		assert.Equal(t, int32(1), calls.Load())
+
+		// The response was cached for the next calls
+		_, err = client.GetPaymentMethodsForPaymentLink(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, int32(1), calls.Load())
+	})
+
+	t.Run("errors are not cached", func(t *testing.T) {
+		calls.Store(0)
+		client := newClient(nil)
+
+		fail.Store(true)
+		_, err := client.GetPaymentMethodsForPaymentLink(context.Background())
+		require.ErrorIs(t, err, ErrServer)
+
+		fail.Store(false)
+		_, err = client.GetPaymentMethodsForPaymentLink(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, int32(2), calls.Load())
+	})
+
+	t.Run("invalidation and bypass", func(t *testing.T) {
+		calls.Store(0)
+		store := NewMemoryCache()
+		client := newClient(store)
+
+		_, err := client.GetPaymentMethodsForPaymentLink(context.Background())
+		require.NoError(t, err)
+
+		require.NoError(t, client.InvalidateCache(context.Background(), CacheEntryPaymentMethodsForPaymentLink))
+		_, err = client.GetPaymentMethodsForPaymentLink(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, int32(2), calls.Load())
+
+		_, err = client.GetPaymentMethodsForPaymentLink(context.Background(), WithCacheBypass())
+		require.NoError(t, err)
+		assert.Equal(t, int32(3), calls.Load())
+
+		// Clients with other credentials do not share the entries
+		other := NewClient(ClientConfig{ApiKey: "other-api-key", BaseURL: server.URL, Cache: &CacheConfig{Cache: store}})
+		_, err = other.GetPaymentMethodsForPaymentLink(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, int32(4), calls.Load())
+	})
+
+	t.Run("entries expire", func(t *testing.T) {
+		store := NewMemoryCache()
+		require.NoError(t, store.Set(context.Background(), "key", []byte("value"), time.Millisecond))
+
+		time.Sleep(5 * time.Millisecond)
+		_, ok, err := store.Get(context.Background(), "key")
+		require.NoError(t, err)
+		assert.False(t, ok)
+	})
+}
@@ -8,15 +8,28 @@ import (
 
 // CreatePaymentForIntegrationsAPI sends a request to create a payment using the integrations API.
 // It accepts a context and a CreatePaymentForIntegrationsAPIRequest with the necessary parameters.
+// The request is validated before being sent, unless ClientConfig.DisableValidation
+// is set; invalid requests fail with a RequestError wrapping a *definitions.ValidationError.
 // Returns the API response with the payment details or an error.
-func (client *BoldClient) CreatePaymentForIntegrationsAPI(ctx context.Context, req definitions.CreatePaymentForIntegrationsAPIRequest) (*definitions.CreatePaymentForIntegrationsAPIResponse, error) {
-	return sendPOSTRequest[definitions.CreatePaymentForIntegrationsAPIResponse](
-		client,
-		ctx,
-		RequestParams{
-			Endpoint: "/payments/app-checkout",
-			Action:   "create payment for integrations API",
-			Body:     req,
-		},
-	)
+func (client *BoldClient) CreatePaymentForIntegrationsAPI(ctx context.Context, req definitions.CreatePaymentForIntegrationsAPIRequest, opts ...RequestOption) (response *definitions.CreatePaymentForIntegrationsAPIResponse, err error) {
+	params := RequestParams{
+		Endpoint: "/payments/app-checkout",
+		Action:   "create payment for integrations API",
+		Body:     req,
+	}
+
+	ctx, span := client.startSpan(ctx, params)
+	defer func() { span.end(err) }()
+
+	if err := client.validate(params, req); err != nil {
+		return nil, err
+	}
+
+	response, err = sendPOSTRequest[definitions.CreatePaymentForIntegrationsAPIResponse](client, ctx, params, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	span.setAttributes(AttributeIntegrationID.String(response.Payload.IntegrationID))
+	return response, nil
 }
@@ -2,27 +2,22 @@ package sdk
 
 import (
 	"context"
-	"os"
+	"net/http"
+	"net/http/httptest"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/PChaparro/bold-co-sdk/src/definitions"
 	"github.com/PChaparro/bold-co-sdk/src/internal/tests"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
 func TestCreatePaymentForIntegrationsAPI(t *testing.T) {
-	// Get API key from environment variable
-	apiKey := os.Getenv("BOLD_API_KEY")
-	if apiKey == "" {
-		t.Skip("BOLD_API_KEY environment variable not set")
-	}
-
-	// Initialize the client
-	client := NewClient(ClientConfig{
-		ApiKey: apiKey,
-	})
+	// Initialize the client for the sandbox, or for the test server without an API key
+	client := newSandboxClient(t)
 
 	// Create context with timeout
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
@@ -42,3 +37,39 @@ func TestCreatePaymentForIntegrationsAPI(t *testing.T) {
 		assert.Nil(t, response)
 	})
 }
+
+func TestCreatePaymentForIntegrationsAPIValidation(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		_, _ = w.Write([]byte(`{"payload":{"integration_id":"INT_TEST"},"errors":[]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(ClientConfig{ApiKey: "test-api-key", BaseURL: server.URL})
+
+	t.Run("invalid requests are not sent", func(t *testing.T) {
+		req := tests.GetPayloadToCreateValidPaymentForIntegrationsAPI()
+		req.TerminalSerial = ""
+		req.PaymentMethod = definitions.PaymentMethodPse
+
+		response, err := client.CreatePaymentForIntegrationsAPI(context.Background(), *req)
+
+		require.Error(t, err)
+		assert.Nil(t, response)
+		assert.Zero(t, calls.Load())
+
+		var validationErr *definitions.ValidationError
+		require.ErrorAs(t, err, &validationErr)
+		assert.True(t, validationErr.HasField("terminal_serial"))
+		assert.True(t, validationErr.HasField("payment_method"))
+	})
+
+	t.Run("valid requests are sent", func(t *testing.T) {
+		response, err := client.CreatePaymentForIntegrationsAPI(context.Background(), *tests.GetPayloadToCreateValidPaymentForIntegrationsAPI())
+
+		require.NoError(t, err)
+		assert.Equal(t, "INT_TEST", response.Payload.IntegrationID)
+		assert.Equal(t, int32(1), calls.Load())
+	})
+}
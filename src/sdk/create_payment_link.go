@@ -8,15 +8,35 @@ import (
 
 // CreatePaymentLink sends a request to create a payment link using Bold's API.
 // It accepts a context and a CreatePaymentLinkRequest with the necessary parameters.
+// The request is validated before being sent, unless ClientConfig.DisableValidation
+// is set; invalid requests fail with a RequestError wrapping a *definitions.ValidationError.
+// If ClientConfig.Preflight is set, the payment methods are also checked against their limits.
 // Returns the API response with the payment link details or an error
-func (client *BoldClient) CreatePaymentLink(ctx context.Context, req definitions.CreatePaymentLinkRequest) (*definitions.CreatePaymentLinkResponse, error) {
-	return sendPOSTRequest[definitions.CreatePaymentLinkResponse](
-		client,
-		ctx,
-		RequestParams{
-			Endpoint: "/online/link/v1",
-			Action:   "create payment link",
-			Body:     req,
-		},
-	)
+func (client *BoldClient) CreatePaymentLink(ctx context.Context, req definitions.CreatePaymentLinkRequest, opts ...RequestOption) (response *definitions.CreatePaymentLinkResponse, err error) {
+	params := RequestParams{
+		Endpoint: "/online/link/v1",
+		Action:   "create payment link",
+		Body:     req,
+	}
+
+	ctx, span := client.startSpan(ctx, params)
+	defer func() { span.end(err) }()
+
+	if err := client.validate(params, req); err != nil {
+		return nil, err
+	}
+
+	req, err = client.preflightPaymentLink(ctx, params, req)
+	if err != nil {
+		return nil, err
+	}
+	params.Body = req
+
+	response, err = sendPOSTRequest[definitions.CreatePaymentLinkResponse](client, ctx, params, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	span.setAttributes(AttributePaymentLinkID.String(response.Payload.PaymentLink))
+	return response, nil
 }
@@ -2,7 +2,9 @@ package sdk
 
 import (
 	"context"
-	"os"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -13,16 +15,8 @@ import (
 )
 
 func TestCreatePaymentLink(t *testing.T) {
-	// Get API key from environment variable
-	apiKey := os.Getenv("BOLD_API_KEY")
-	if apiKey == "" {
-		t.Skip("BOLD_API_KEY environment variable not set")
-	}
-
-	// Initialize the client
-	client := NewClient(ClientConfig{
-		ApiKey: apiKey,
-	})
+	// Initialize the client for the sandbox, or for the test server without an API key
+	client := newSandboxClient(t)
 
 	// Create context with timeout
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
@@ -57,3 +51,59 @@ func TestCreatePaymentLink(t *testing.T) {
 		require.Nil(t, response)
 	})
 }
+
+func TestCreatePaymentLinkValidation(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		_, _ = w.Write([]byte(`{"payload":{"payment_link":"LNK_TEST","url":"https://checkout.bold.co/LNK_TEST"},"errors":[]}`))
+	}))
+	defer server.Close()
+
+	invalid := definitions.CreatePaymentLinkRequest{
+		AmountType:  definitions.AmountTypeClose,
+		Description: "x",
+		CallbackURL: "http://example.com/callback",
+	}
+
+	t.Run("invalid requests are not sent", func(t *testing.T) {
+		client := NewClient(ClientConfig{ApiKey: "test-api-key", BaseURL: server.URL})
+
+		response, err := client.CreatePaymentLink(context.Background(), invalid)
+
+		require.Error(t, err)
+		assert.Nil(t, response)
+		assert.Zero(t, calls.Load())
+
+		var requestErr *RequestError
+		require.ErrorAs(t, err, &requestErr)
+		assert.Equal(t, "create payment link", requestErr.Action)
+		assert.Zero(t, requestErr.Attempts)
+
+		var validationErr *definitions.ValidationError
+		require.ErrorAs(t, err, &validationErr)
+		assert.True(t, validationErr.HasField("amount"))
+		assert.True(t, validationErr.HasField("description"))
+		assert.True(t, validationErr.HasField("callback_url"))
+	})
+
+	t.Run("valid requests are sent", func(t *testing.T) {
+		client := NewClient(ClientConfig{ApiKey: "test-api-key", BaseURL: server.URL})
+
+		response, err := client.CreatePaymentLink(context.Background(), *tests.GetPayloadToCreateValidPaymentLink())
+
+		require.NoError(t, err)
+		assert.Equal(t, "LNK_TEST", response.Payload.PaymentLink)
+		assert.Equal(t, int32(1), calls.Load())
+	})
+
+	t.Run("validation can be disabled", func(t *testing.T) {
+		calls.Store(0)
+		client := NewClient(ClientConfig{ApiKey: "test-api-key", BaseURL: server.URL, DisableValidation: true})
+
+		_, err := client.CreatePaymentLink(context.Background(), invalid)
+
+		require.NoError(t, err)
+		assert.Equal(t, int32(1), calls.Load())
+	})
+}
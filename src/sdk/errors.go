@@ -0,0 +1,149 @@
+package sdk
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/PChaparro/bold-co-sdk/src/definitions"
+	httpClient "github.com/PChaparro/bold-co-sdk/src/internal/http"
+)
+
+// Sentinel errors that classify the failures returned by the Bold API.
+// They can be used with errors.Is on any error returned by a BoldClient method.
+var (
+	// ErrNotFound is returned when the requested resource does not exist (HTTP 404).
+	ErrNotFound = errors.New("bold: resource not found")
+
+	// ErrUnauthorized is returned when the API key is missing, invalid or
+	// lacks the permissions required for the operation (HTTP 401 or 403).
+	ErrUnauthorized = errors.New("bold: unauthorized")
+
+	// ErrValidation is returned when Bold rejects the request payload (HTTP 400 or 422).
+	ErrValidation = errors.New("bold: validation error")
+
+	// ErrRateLimited is returned when too many requests were sent to Bold (HTTP 429).
+	ErrRateLimited = errors.New("bold: rate limited")
+
+	// ErrServer is returned when Bold fails to process the request (HTTP 5xx).
+	ErrServer = errors.New("bold: server error")
+)
+
+// APIError represents a non-successful response returned by the Bold API.
+// Use errors.As to retrieve it from the errors returned by the BoldClient methods.
+type APIError struct {
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int
+
+	// Body is the raw response body.
+	Body []byte
+
+	// Errors contains the error fields parsed from the response body, if any.
+	Errors []definitions.ErrorField
+
+	// Endpoint is the endpoint path of the request, not including the base URL.
+	Endpoint string
+
+	// Action is the description of the action being performed (e.g., "create payment link").
+	Action string
+
+	// Headers contains the response headers.
+	Headers http.Header
+
+	// Attempts is the number of attempts performed before giving up.
+	Attempts int
+}
+
+// Error implements the error interface.
+func (e *APIError) Error() string {
+	return fmt.Sprintf("failed to %s: bold API error - status code: %d, response: %s",
+		e.Action, e.StatusCode, string(e.Body))
+}
+
+// Is reports whether the error matches one of the sentinel errors of the package,
+// based on the HTTP status code of the response.
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden
+	case ErrValidation:
+		return e.StatusCode == http.StatusBadRequest || e.StatusCode == http.StatusUnprocessableEntity
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	case ErrServer:
+		return e.StatusCode >= http.StatusInternalServerError
+	}
+
+	return false
+}
+
+// RequestError is returned when a request to the Bold API fails without
+// getting a response (e.g., connection errors, timeouts or requests rejected
+// by the client-side validation before being sent).
+type RequestError struct {
+	// Endpoint is the endpoint path of the request, not including the base URL.
+	Endpoint string
+
+	// Action is the description of the action being performed (e.g., "create payment link").
+	Action string
+
+	// Attempts is the number of attempts performed before giving up.
+	// It is zero when the request was not sent.
+	Attempts int
+
+	// Err is the underlying error.
+	Err error
+}
+
+// Error implements the error interface.
+func (e *RequestError) Error() string {
+	return fmt.Sprintf("failed to %s: %v", e.Action, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *RequestError) Unwrap() error {
+	return e.Err
+}
+
+// newAPIError builds an APIError from a non-successful response.
+// The error fields are parsed on a best-effort basis, since Bold does not
+// always return a JSON body on failures.
+func newAPIError(params RequestParams, response *Response) *APIError {
+	var parsed struct {
+		Errors []definitions.ErrorField `json:"errors"`
+	}
+	_ = json.Unmarshal(response.Body, &parsed)
+
+	return &APIError{
+		StatusCode: response.StatusCode,
+		Body:       response.Body,
+		Errors:     parsed.Errors,
+		Endpoint:   params.Endpoint,
+		Action:     params.Action,
+		Headers:    response.Headers,
+		Attempts:   response.Attempts,
+	}
+}
+
+// newRequestError builds a RequestError from an error returned by the internal
+// HTTP client. The attempts are taken from the retry loop; errors returned
+// before it (e.g., building the request) did not send any attempt.
+func newRequestError(params RequestParams, err error) *RequestError {
+	attempts := 0
+
+	var retryErr *httpClient.RetryError
+	if errors.As(err, &retryErr) {
+		attempts = retryErr.Attempts
+		err = retryErr.Err
+	}
+
+	return &RequestError{
+		Endpoint: params.Endpoint,
+		Action:   params.Action,
+		Attempts: attempts,
+		Err:      err,
+	}
+}
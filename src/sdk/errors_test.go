@@ -0,0 +1,120 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIError(t *testing.T) {
+	cases := []struct {
+		name       string
+		statusCode int
+		sentinel   error
+	}{
+		{"not found", http.StatusNotFound, ErrNotFound},
+		{"unauthorized", http.StatusUnauthorized, ErrUnauthorized},
+		{"forbidden", http.StatusForbidden, ErrUnauthorized},
+		{"bad request", http.StatusBadRequest, ErrValidation},
+		{"unprocessable entity", http.StatusUnprocessableEntity, ErrValidation},
+		{"too many requests", http.StatusTooManyRequests, ErrRateLimited},
+		{"internal server error", http.StatusInternalServerError, ErrServer},
+		{"bad gateway", http.StatusBadGateway, ErrServer},
+	}
+
+	sentinels := []error{ErrNotFound, ErrUnauthorized, ErrValidation, ErrRateLimited, ErrServer}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.Header().Set("X-Request-Id", "req-123")
+				w.WriteHeader(tc.statusCode)
+				_, _ = w.Write([]byte(`{"payload":{},"errors":[{"code":"ERR","message":"Something went wrong"}]}`))
+			}))
+			defer server.Close()
+
+			client := NewClient(ClientConfig{
+				ApiKey:  "test-api-key",
+				BaseURL: server.URL,
+			})
+
+			response, err := client.GetPaymentMethodsForPaymentLink(context.Background())
+			require.Error(t, err)
+			assert.Nil(t, response)
+
+			// Check the sentinel errors
+			for _, sentinel := range sentinels {
+				assert.Equal(t, sentinel == tc.sentinel, errors.Is(err, sentinel), "errors.Is(err, %v)", sentinel)
+			}
+
+			// Check the error details
+			var apiErr *APIError
+			require.ErrorAs(t, err, &apiErr)
+			assert.Equal(t, tc.statusCode, apiErr.StatusCode)
+			assert.Equal(t, "/online/link/v1/payment_methods", apiErr.Endpoint)
+			assert.Equal(t, "get available payment methods for payment link", apiErr.Action)
+			assert.Equal(t, "req-123", apiErr.Headers.Get("X-Request-Id"))
+			require.Len(t, apiErr.Errors, 1)
+			assert.Equal(t, "Something went wrong", apiErr.Errors[0]["message"])
+			assert.Contains(t, err.Error(), "Something went wrong")
+		})
+	}
+
+	t.Run("non JSON body", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadGateway)
+			_, _ = w.Write([]byte("<html>Bad Gateway</html>"))
+		}))
+		defer server.Close()
+
+		client := NewClient(ClientConfig{
+			ApiKey:  "test-api-key",
+			BaseURL: server.URL,
+		})
+
+		_, err := client.GetPaymentLinkData(context.Background(), "LNK_TEST")
+		require.ErrorIs(t, err, ErrServer)
+
+		var apiErr *APIError
+		require.ErrorAs(t, err, &apiErr)
+		assert.Empty(t, apiErr.Errors)
+		assert.Equal(t, "<html>Bad Gateway</html>", string(apiErr.Body))
+	})
+}
+
+func TestRequestError(t *testing.T) {
+	refused := httptest.NewServer(http.NotFoundHandler())
+	refused.Close()
+
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	cases := []struct {
+		name     string
+		baseURL  string
+		ctx      context.Context
+		attempts int
+	}{
+		{"invalid URL", "http://[::1", context.Background(), 0},
+		{"canceled context", refused.URL, canceled, 0},
+		{"refused connection", refused.URL, context.Background(), 1},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := NewClient(ClientConfig{ApiKey: "test-api-key", BaseURL: tc.baseURL})
+
+			_, err := client.GetPaymentMethodsForPaymentLink(tc.ctx)
+
+			var requestErr *RequestError
+			require.ErrorAs(t, err, &requestErr)
+			assert.Equal(t, tc.attempts, requestErr.Attempts)
+		})
+	}
+}
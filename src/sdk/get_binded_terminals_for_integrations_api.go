@@ -8,13 +8,15 @@ import (
 
 // GetBindedTerminalsForIntegrationsAPI retrieves the binded terminals
 // that can be used with the integrations API.
-func (client *BoldClient) GetBindedTerminalsForIntegrationsAPI(ctx context.Context) (*definitions.GetBindedTerminalsForIntegrationsAPIResponse, error) {
-	return sendGETRequest[definitions.GetBindedTerminalsForIntegrationsAPIResponse](
-		client,
-		ctx,
-		RequestParams{
-			Endpoint: "/payments/binded-terminals",
-			Action:   "get binded terminals for integrations API",
-		},
-	)
+func (client *BoldClient) GetBindedTerminalsForIntegrationsAPI(ctx context.Context, opts ...RequestOption) (_ *definitions.GetBindedTerminalsForIntegrationsAPIResponse, err error) {
+	params := RequestParams{
+		Endpoint:   "/payments/binded-terminals",
+		Action:     "get binded terminals for integrations API",
+		CacheEntry: CacheEntryBindedTerminalsForIntegrationsAPI,
+	}
+
+	ctx, span := client.startSpan(ctx, params)
+	defer func() { span.end(err) }()
+
+	return sendGETRequest[definitions.GetBindedTerminalsForIntegrationsAPIResponse](client, ctx, params, opts...)
 }
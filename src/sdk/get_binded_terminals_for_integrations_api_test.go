@@ -2,25 +2,19 @@ package sdk
 
 import (
 	"context"
-	"os"
+	"net/http"
 	"testing"
 	"time"
 
+	"github.com/PChaparro/bold-co-sdk/src/boldtest"
+	"github.com/PChaparro/bold-co-sdk/src/definitions"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
 func TestGetBindedTerminalsForIntegrationsAPI(t *testing.T) {
-	// Get API key from environment variable
-	apiKey := os.Getenv("BOLD_API_KEY")
-	if apiKey == "" {
-		t.Skip("BOLD_API_KEY environment variable not set")
-	}
-
-	// Initialize the client
-	client := NewClient(ClientConfig{
-		ApiKey: apiKey,
-	})
+	// Initialize the client for the sandbox, or for the test server without an API key
+	client := newSandboxClient(t)
 
 	// Create context with timeout
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
@@ -67,6 +61,35 @@ func TestGetBindedTerminalsForIntegrationsAPI(t *testing.T) {
 		// Assert response
 		require.Error(t, err)
 		require.Nil(t, response)
+		assert.ErrorIs(t, err, ErrNotFound)
 		assert.Contains(t, err.Error(), "Available terminals not found", "Error message should contain 'Available terminals not found'")
+
+		var apiErr *APIError
+		require.ErrorAs(t, err, &apiErr)
+		assert.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+		assert.Equal(t, "/payments/binded-terminals", apiErr.Endpoint)
+	})
+}
+
+func TestGetBindedTerminalsForIntegrationsAPIWithTestServer(t *testing.T) {
+	server := boldtest.NewServer(boldtest.Config{
+		Terminals: []boldtest.Terminal{{Model: "N86", Serial: "N860W000000", Name: "Front desk"}},
+	})
+	defer server.Close()
+
+	client := NewClient(ClientConfig{ApiKey: boldtest.DefaultAPIKey, BaseURL: server.URL})
+
+	t.Run("successful binded terminals retrieval", func(t *testing.T) {
+		response, err := client.GetBindedTerminalsForIntegrationsAPI(context.Background())
+
+		require.NoError(t, err)
+		require.NotNil(t, response.Payload.AvailableTerminals)
+		assert.Equal(t, []definitions.TerminalInfo{{
+			TerminalModel:  "N86",
+			TerminalSerial: "N860W000000",
+			Status:         definitions.TerminalStatusBinded,
+			Name:           "Front desk",
+		}}, *response.Payload.AvailableTerminals)
+		assert.Empty(t, response.Errors)
 	})
 }
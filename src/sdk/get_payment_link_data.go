@@ -10,13 +10,16 @@ import (
 func (client *BoldClient) GetPaymentLinkData(
 	ctx context.Context,
 	paymentLinkId string,
-) (*definitions.GetPaymentLinkDataResponse, error) {
-	return sendGETRequest[definitions.GetPaymentLinkDataResponse](
-		client,
-		ctx,
-		RequestParams{
-			Endpoint: fmt.Sprintf("/online/link/v1/%s", paymentLinkId),
-			Action:   "get data of payment link",
-		},
-	)
+	opts ...RequestOption,
+) (_ *definitions.GetPaymentLinkDataResponse, err error) {
+	params := RequestParams{
+		Endpoint:      fmt.Sprintf("/online/link/v1/%s", paymentLinkId),
+		Action:        "get data of payment link",
+		PaymentLinkID: paymentLinkId,
+	}
+
+	ctx, span := client.startSpan(ctx, params)
+	defer func() { span.end(err) }()
+
+	return sendGETRequest[definitions.GetPaymentLinkDataResponse](client, ctx, params, opts...)
 }
@@ -8,13 +8,15 @@ import (
 
 // GetPaymentMethodsForIntegrationsAPI retrieves the available payment methods that can be used
 // with the integrations API.
-func (client *BoldClient) GetPaymentMethodsForIntegrationsAPI(ctx context.Context) (*definitions.GetPaymentMethodsForIntegrationsAPIResponse, error) {
-	return sendGETRequest[definitions.GetPaymentMethodsForIntegrationsAPIResponse](
-		client,
-		ctx,
-		RequestParams{
-			Endpoint: "/payments/payment-methods",
-			Action:   "get available payment methods for integrations API",
-		},
-	)
+func (client *BoldClient) GetPaymentMethodsForIntegrationsAPI(ctx context.Context, opts ...RequestOption) (_ *definitions.GetPaymentMethodsForIntegrationsAPIResponse, err error) {
+	params := RequestParams{
+		Endpoint:   "/payments/payment-methods",
+		Action:     "get available payment methods for integrations API",
+		CacheEntry: CacheEntryPaymentMethodsForIntegrationsAPI,
+	}
+
+	ctx, span := client.startSpan(ctx, params)
+	defer func() { span.end(err) }()
+
+	return sendGETRequest[definitions.GetPaymentMethodsForIntegrationsAPIResponse](client, ctx, params, opts...)
 }
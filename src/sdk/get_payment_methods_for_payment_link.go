@@ -8,13 +8,15 @@ import (
 
 // GetPaymentMethodsForPaymentLink retrieves the available payment methods that can be used
 // for creating a payment link.
-func (client *BoldClient) GetPaymentMethodsForPaymentLink(ctx context.Context) (*definitions.GetPaymentMethodsForPaymentLinkResponse, error) {
-	return sendGETRequest[definitions.GetPaymentMethodsForPaymentLinkResponse](
-		client,
-		ctx,
-		RequestParams{
-			Endpoint: "/online/link/v1/payment_methods",
-			Action:   "get available payment methods for payment link",
-		},
-	)
+func (client *BoldClient) GetPaymentMethodsForPaymentLink(ctx context.Context, opts ...RequestOption) (_ *definitions.GetPaymentMethodsForPaymentLinkResponse, err error) {
+	params := RequestParams{
+		Endpoint:   "/online/link/v1/payment_methods",
+		Action:     "get available payment methods for payment link",
+		CacheEntry: CacheEntryPaymentMethodsForPaymentLink,
+	}
+
+	ctx, span := client.startSpan(ctx, params)
+	defer func() { span.end(err) }()
+
+	return sendGETRequest[definitions.GetPaymentMethodsForPaymentLinkResponse](client, ctx, params, opts...)
 }
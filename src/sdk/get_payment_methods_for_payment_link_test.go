@@ -2,7 +2,6 @@ package sdk
 
 import (
 	"context"
-	"os"
 	"testing"
 	"time"
 
@@ -11,16 +10,8 @@ import (
 )
 
 func TestGetPaymentMethodsForPaymentLink(t *testing.T) {
-	// Get API key from environment variable
-	apiKey := os.Getenv("BOLD_API_KEY")
-	if apiKey == "" {
-		t.Skip("BOLD_API_KEY environment variable not set")
-	}
-
-	// Initialize the client
-	client := NewClient(ClientConfig{
-		ApiKey: apiKey,
-	})
+	// Initialize the client for the sandbox, or for the test server without an API key
+	client := newSandboxClient(t)
 
 	// Create context with timeout
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
@@ -0,0 +1,575 @@
+package sdk
+
+import (
+	"container/heap"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/PChaparro/bold-co-sdk/src/definitions"
+)
+
+// Default values of the LinkWatcherConfig.
+const (
+	DefaultLinkWatcherWorkers     = 4
+	DefaultLinkWatcherRateLimit   = 5
+	DefaultLinkWatcherMinInterval = 5 * time.Second
+	DefaultLinkWatcherMaxInterval = 5 * time.Minute
+	DefaultLinkWatcherEventBuffer = 100
+)
+
+// ErrWatcherStopped is returned by LinkWatcher.Watch once Run has returned,
+// since the added payment links would never be polled.
+var ErrWatcherStopped = errors.New("the link watcher is stopped")
+
+// ErrTooManyPollErrors is reported through LinkWatcherConfig.OnError, wrapping
+// the last error, when a payment link stops being watched because too many
+// consecutive polls failed (see LinkWatcherConfig.MaxErrors).
+var ErrTooManyPollErrors = errors.New("too many consecutive errors polling the payment link")
+
+// WatchedLink is the progress of a payment link watched by a LinkWatcher.
+type WatchedLink struct {
+	// ID is the identifier of the payment link.
+	ID string
+
+	// Status is the last observed status. It is empty before the first poll.
+	Status definitions.PaymentLinkStatus
+
+	// AddedAt is when the payment link was added to the watcher.
+	AddedAt time.Time
+
+	// ExpiresAt is the expiration date of the payment link.
+	// It is zero if the link does not expire or was not polled yet.
+	ExpiresAt time.Time
+
+	// LastPolledAt is when the payment link was last polled.
+	LastPolledAt time.Time
+
+	// NextPollAt is when the payment link will be polled again.
+	NextPollAt time.Time
+
+	// Errors is the number of consecutive polls that failed.
+	Errors int
+
+	// Details is the last observed data of the payment link, compared with the
+	// next observation to detect impossible changes. It is nil before the first poll.
+	Details *definitions.PaymentLinkDetails
+}
+
+// LinkWatcherStore persists the progress of the watched payment links, so a
+// LinkWatcher can resume watching them after a restart. Implementations must
+// be safe for concurrent use.
+type LinkWatcherStore interface {
+	// Save creates or updates the progress of a payment link.
+	Save(ctx context.Context, link WatchedLink) error
+
+	// Delete removes a payment link that no longer needs to be watched.
+	Delete(ctx context.Context, id string) error
+
+	// List returns every payment link being watched.
+	List(ctx context.Context) ([]WatchedLink, error)
+}
+
+// LinkWatcherConfig contains the options of a LinkWatcher. Every field is optional.
+type LinkWatcherConfig struct {
+	// Workers is the maximum number of concurrent polls. Defaults to DefaultLinkWatcherWorkers.
+	Workers int
+
+	// RateLimit is the maximum number of polls per second, across all the
+	// workers. Defaults to DefaultLinkWatcherRateLimit.
+	RateLimit float64
+
+	// MinInterval is the delay between the polls of a recently added payment
+	// link. It grows with the age of the link up to MaxInterval.
+	// Defaults to DefaultLinkWatcherMinInterval.
+	MinInterval time.Duration
+
+	// MaxInterval caps the delay between two polls of a payment link.
+	// Defaults to DefaultLinkWatcherMaxInterval.
+	MaxInterval time.Duration
+
+	// GracePeriod is how long a payment link is polled after its expiration
+	// date, waiting for Bold to report its final status. Defaults to DefaultWaitGracePeriod.
+	GracePeriod time.Duration
+
+	// Schedule overrides how the next poll of a payment link is scheduled.
+	// It receives the progress of the link after a poll and returns when it
+	// must be polled again.
+	Schedule func(link WatchedLink, now time.Time) time.Time
+
+	// Store persists the progress of the payment links.
+	// If not provided, the progress is kept in memory (see NewMemoryLinkWatcherStore).
+	Store LinkWatcherStore
+
+	// MaxErrors is the number of consecutive transient errors (e.g., rate
+	// limits, server or connection errors) tolerated for a payment link before
+	// it stops being watched with ErrTooManyPollErrors. Defaults to DefaultWaitMaxErrors.
+	MaxErrors int
+
+	// EventBuffer is the capacity of the Events channel. Defaults to DefaultLinkWatcherEventBuffer.
+	EventBuffer int
+
+	// OnError is called when a payment link cannot be polled, when it fails
+	// too many consecutive polls (ErrTooManyPollErrors), when its expiration
+	// date passes without a final status (ErrPaymentLinkExpired),
+	// when an impossible status change is observed (see
+	// definitions.CheckPaymentLinkTransition) and when the store fails.
+	OnError func(id string, err error)
+
+	// RequestOptions are applied to every GetPaymentLinkData call.
+	RequestOptions []RequestOption
+}
+
+// LinkWatcher polls many payment links until they reach a final status,
+// emitting their status changes on a channel. The delay between the polls
+// of a link grows with its age, and links are polled right after they expire.
+//
+// Links are added with Watch, and polled while Run is running.
+type LinkWatcher struct {
+	client BoldAPI
+	config LinkWatcherConfig
+	events chan PaymentLinkStatusChange
+	rate   *pollRateLimiter
+
+	mu      sync.Mutex
+	links   map[string]*watchedLinkEntry
+	queue   linkQueue
+	wake    chan struct{}
+	running bool
+	stopped bool
+}
+
+// NewLinkWatcher creates a LinkWatcher that polls the payment links using the
+// given client (usually a *BoldClient).
+func NewLinkWatcher(client BoldAPI, config LinkWatcherConfig) *LinkWatcher {
+	if config.Workers <= 0 {
+		config.Workers = DefaultLinkWatcherWorkers
+	}
+	if config.RateLimit <= 0 {
+		config.RateLimit = DefaultLinkWatcherRateLimit
+	}
+	if config.MinInterval <= 0 {
+		config.MinInterval = DefaultLinkWatcherMinInterval
+	}
+	if config.MaxInterval <= 0 {
+		config.MaxInterval = DefaultLinkWatcherMaxInterval
+	}
+	if config.GracePeriod <= 0 {
+		config.GracePeriod = DefaultWaitGracePeriod
+	}
+	if config.Store == nil {
+		config.Store = NewMemoryLinkWatcherStore()
+	}
+	if config.MaxErrors <= 0 {
+		config.MaxErrors = DefaultWaitMaxErrors
+	}
+	if config.EventBuffer <= 0 {
+		config.EventBuffer = DefaultLinkWatcherEventBuffer
+	}
+
+	return &LinkWatcher{
+		client: client,
+		config: config,
+		events: make(chan PaymentLinkStatusChange, config.EventBuffer),
+		rate:   &pollRateLimiter{interval: time.Duration(float64(time.Second) / config.RateLimit)},
+		links:  make(map[string]*watchedLinkEntry),
+		wake:   make(chan struct{}, 1),
+	}
+}
+
+// Events returns the channel where the status changes are emitted, including
+// the first observed status of every link. It is closed when Run returns.
+// The watcher blocks when the channel is full, so it must be drained.
+func (w *LinkWatcher) Events() <-chan PaymentLinkStatusChange {
+	return w.events
+}
+
+// Watch adds payment links to the watcher. They are polled as soon as
+// possible. Links that are already being watched are ignored.
+// It returns ErrWatcherStopped once Run has returned.
+func (w *LinkWatcher) Watch(ctx context.Context, ids ...string) error {
+	now := time.Now()
+	for _, id := range ids {
+		if err := w.watch(ctx, WatchedLink{ID: id, AddedAt: now, NextPollAt: now}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// watch adds a payment link and saves it in the store. The mutex is held while
+// saving, so the link cannot be polled (and deleted from the store when it
+// reaches a final status) before it is saved. The link is not watched if it
+// cannot be saved.
+func (w *LinkWatcher) watch(ctx context.Context, link WatchedLink) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	added, err := w.addLocked(link)
+	if err != nil || !added {
+		return err
+	}
+
+	if err := w.config.Store.Save(ctx, link); err != nil {
+		entry := w.links[link.ID]
+		delete(w.links, link.ID)
+		heap.Remove(&w.queue, entry.index)
+		return fmt.Errorf("failed to save the watched payment link %s: %w", link.ID, err)
+	}
+	return nil
+}
+
+// Len returns the number of payment links being watched.
+func (w *LinkWatcher) Len() int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return len(w.links)
+}
+
+// Run polls the watched payment links until the context is done, resuming
+// the links saved in the store. It closes the Events channel when it returns,
+// so it can only be called once.
+func (w *LinkWatcher) Run(ctx context.Context) error {
+	w.mu.Lock()
+	if w.running {
+		w.mu.Unlock()
+		return errors.New("the link watcher can only be run once")
+	}
+	w.running = true
+	w.mu.Unlock()
+	defer close(w.events)
+	defer func() {
+		w.mu.Lock()
+		w.stopped = true
+		w.mu.Unlock()
+	}()
+
+	saved, err := w.config.Store.List(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list the watched payment links: %w", err)
+	}
+	for _, link := range saved {
+		_, _ = w.add(link)
+	}
+
+	jobs := make(chan WatchedLink)
+	var wg sync.WaitGroup
+	for range w.config.Workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for link := range jobs {
+				w.poll(ctx, link)
+			}
+		}()
+	}
+
+	w.dispatch(ctx, jobs)
+	close(jobs)
+	wg.Wait()
+
+	return ctx.Err()
+}
+
+// add schedules a payment link, reporting whether it was not already watched.
+func (w *LinkWatcher) add(link WatchedLink) (bool, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.addLocked(link)
+}
+
+// addLocked is like add, but it must be called with the mutex held.
+func (w *LinkWatcher) addLocked(link WatchedLink) (bool, error) {
+	if w.stopped {
+		return false, ErrWatcherStopped
+	}
+	if _, ok := w.links[link.ID]; ok {
+		return false, nil
+	}
+
+	entry := &watchedLinkEntry{link: link}
+	w.links[link.ID] = entry
+	heap.Push(&w.queue, entry)
+	w.notify()
+	return true, nil
+}
+
+// notify wakes up the dispatcher. It must be called with the mutex held.
+func (w *LinkWatcher) notify() {
+	select {
+	case w.wake <- struct{}{}:
+	default:
+	}
+}
+
+// dispatch sends the payment links to the workers when they are due, until the context is done.
+func (w *LinkWatcher) dispatch(ctx context.Context, jobs chan<- WatchedLink) {
+	timer := time.NewTimer(time.Hour)
+	defer timer.Stop()
+
+	for {
+		w.mu.Lock()
+		var delay time.Duration = -1
+		var due *watchedLinkEntry
+		if len(w.queue) > 0 {
+			next := w.queue[0]
+			if delay = time.Until(next.link.NextPollAt); delay <= 0 {
+				due = heap.Pop(&w.queue).(*watchedLinkEntry)
+			}
+		}
+		w.mu.Unlock()
+
+		if due != nil {
+			select {
+			case jobs <- due.link:
+				continue
+			case <-ctx.Done():
+				return
+			}
+		}
+
+		var timeout <-chan time.Time
+		if delay > 0 {
+			timer.Reset(delay)
+			timeout = timer.C
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-w.wake:
+		case <-timeout:
+		}
+	}
+}
+
+// poll fetches the status of a payment link, emitting its changes and
+// scheduling its next poll or removing it when it reaches a final status.
+func (w *LinkWatcher) poll(ctx context.Context, link WatchedLink) {
+	if err := w.rate.wait(ctx); err != nil {
+		return
+	}
+
+	now := time.Now()
+	link.LastPolledAt = now
+	response, err := w.client.GetPaymentLinkData(ctx, link.ID, w.config.RequestOptions...)
+	if ctx.Err() != nil {
+		return
+	}
+
+	switch {
+	case err != nil && !isTransientError(err):
+		w.reportError(link.ID, err)
+		w.remove(ctx, link.ID)
+		return
+	case err != nil:
+		link.Errors++
+		if link.Errors > w.config.MaxErrors {
+			w.reportError(link.ID, fmt.Errorf("%w: %w", ErrTooManyPollErrors, err))
+			w.remove(ctx, link.ID)
+			return
+		}
+		w.reportError(link.ID, err)
+	default:
+		link.Errors = 0
+		details := response.PaymentLinkDetails
+		if details.ExpirationDate != nil {
+			link.ExpiresAt = time.Unix(0, *details.ExpirationDate)
+		}
+
+		// Compare with the last observation (only the status is known for the
+		// links saved without it)
+		if link.Status != "" {
+			previous := definitions.PaymentLinkDetails{ID: link.ID, Status: link.Status}
+			if link.Details != nil {
+				previous = *link.Details
+			}
+			if err := definitions.CheckPaymentLinkTransition(previous, details); err != nil {
+				w.reportError(link.ID, err)
+			}
+		}
+		link.Details = &details
+
+		if details.Status != link.Status {
+
+			change := PaymentLinkStatusChange{
+				PaymentLinkID: link.ID,
+				From:          link.Status,
+				To:            details.Status,
+				Details:       details,
+				ObservedAt:    now,
+			}
+			select {
+			case w.events <- change:
+			case <-ctx.Done():
+				return
+			}
+			link.Status = details.Status
+		}
+
+		if details.Status.IsTerminal() {
+			w.remove(ctx, link.ID)
+			return
+		}
+	}
+
+	if !link.ExpiresAt.IsZero() && now.After(link.ExpiresAt.Add(w.config.GracePeriod)) {
+		w.reportError(link.ID, ErrPaymentLinkExpired)
+		w.remove(ctx, link.ID)
+		return
+	}
+
+	link.NextPollAt = w.schedule(link, now)
+	if err := w.config.Store.Save(ctx, link); err != nil {
+		w.reportError(link.ID, err)
+	}
+
+	w.mu.Lock()
+	if entry, ok := w.links[link.ID]; ok {
+		entry.link = link
+		heap.Push(&w.queue, entry)
+		w.notify()
+	}
+	w.mu.Unlock()
+}
+
+// schedule returns when a payment link must be polled again. The delay grows
+// with the age of the link, and links are polled right after they expire.
+func (w *LinkWatcher) schedule(link WatchedLink, now time.Time) time.Time {
+	if w.config.Schedule != nil {
+		return w.config.Schedule(link, now)
+	}
+
+	// Poll every tenth of the age of the link (e.g., every minute after 10 minutes)
+	interval := min(max(now.Sub(link.AddedAt)/10, w.config.MinInterval), w.config.MaxInterval)
+	next := now.Add(interval)
+
+	if !link.ExpiresAt.IsZero() && now.Before(link.ExpiresAt) && next.After(link.ExpiresAt) {
+		next = link.ExpiresAt
+	}
+	return next
+}
+
+// remove stops watching a payment link.
+func (w *LinkWatcher) remove(ctx context.Context, id string) {
+	w.mu.Lock()
+	delete(w.links, id)
+	w.mu.Unlock()
+
+	if err := w.config.Store.Delete(ctx, id); err != nil {
+		w.reportError(id, err)
+	}
+}
+
+// reportError calls the OnError callback, if any.
+func (w *LinkWatcher) reportError(id string, err error) {
+	if w.config.OnError != nil {
+		w.config.OnError(id, err)
+	}
+}
+
+// watchedLinkEntry is an item of the linkQueue.
+type watchedLinkEntry struct {
+	link  WatchedLink
+	index int
+}
+
+// linkQueue is a priority queue of payment links sorted by their next poll.
+type linkQueue []*watchedLinkEntry
+
+func (q linkQueue) Len() int { return len(q) }
+
+func (q linkQueue) Less(i, j int) bool { return q[i].link.NextPollAt.Before(q[j].link.NextPollAt) }
+
+func (q linkQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+	q[i].index, q[j].index = i, j
+}
+
+func (q *linkQueue) Push(x any) {
+	entry := x.(*watchedLinkEntry)
+	entry.index = len(*q)
+	*q = append(*q, entry)
+}
+
+func (q *linkQueue) Pop() any {
+	old := *q
+	entry := old[len(old)-1]
+	old[len(old)-1] = nil
+	*q = old[:len(old)-1]
+	return entry
+}
+
+// pollRateLimiter spaces the polls evenly, so they never exceed the rate limit.
+type pollRateLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
+// wait blocks until the next poll is allowed or the context is done.
+func (l *pollRateLimiter) wait(ctx context.Context) error {
+	l.mu.Lock()
+	now := time.Now()
+	slot := l.next
+	if slot.Before(now) {
+		slot = now
+	}
+	l.next = slot.Add(l.interval)
+	l.mu.Unlock()
+
+	delay := time.Until(slot)
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// MemoryLinkWatcherStore is an in-memory LinkWatcherStore.
+type MemoryLinkWatcherStore struct {
+	mu    sync.Mutex
+	links map[string]WatchedLink
+}
+
+// NewMemoryLinkWatcherStore creates an empty in-memory store.
+func NewMemoryLinkWatcherStore() *MemoryLinkWatcherStore {
+	return &MemoryLinkWatcherStore{links: make(map[string]WatchedLink)}
+}
+
+// Save implements the LinkWatcherStore interface.
+func (s *MemoryLinkWatcherStore) Save(_ context.Context, link WatchedLink) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.links[link.ID] = link
+	return nil
+}
+
+// Delete implements the LinkWatcherStore interface.
+func (s *MemoryLinkWatcherStore) Delete(_ context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.links, id)
+	return nil
+}
+
+// List implements the LinkWatcherStore interface.
+func (s *MemoryLinkWatcherStore) List(_ context.Context) ([]WatchedLink, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	links := make([]WatchedLink, 0, len(s.links))
+	for _, link := range s.links {
+		links = append(links, link)
+	}
+	return links, nil
+}
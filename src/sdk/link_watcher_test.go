@@ -0,0 +1,305 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/PChaparro/bold-co-sdk/src/definitions"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// failingLinkWatcherStore is a MemoryLinkWatcherStore whose saves can be delayed or fail.
+type failingLinkWatcherStore struct {
+	*MemoryLinkWatcherStore
+	delay time.Duration
+	err   error
+}
+
+func (s *failingLinkWatcherStore) Save(ctx context.Context, link WatchedLink) error {
+	time.Sleep(s.delay)
+	if s.err != nil {
+		return s.err
+	}
+	return s.MemoryLinkWatcherStore.Save(ctx, link)
+}
+
+func TestLinkWatcher(t *testing.T) {
+	// Each link reports its statuses, one per poll, repeating the last one
+	var mu sync.Mutex
+	polls := map[string]int{}
+	statuses := map[string][]string{
+		"LNK_PAID":     {"ACTIVE", "PROCESSING", "PAID"},
+		"LNK_REJECTED": {"REJECTED"},
+		"LNK_ANOMALY":  {"ACTIVE", "ACTIVE", "CANCELED"},
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := strings.TrimPrefix(r.URL.Path, "/online/link/v1/")
+
+		mu.Lock()
+		defer mu.Unlock()
+		if id == "LNK_FAILING" {
+			polls[id]++
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		if id == "LNK_RETRANSACTED" {
+			polls[id]++
+			_, _ = fmt.Fprintf(w, `{"id":%q,"status":"PAID","transaction_id":"TX_%d"}`, id, polls[id])
+			return
+		}
+		sequence, ok := statuses[id]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		polls[id]++
+		status := sequence[min(polls[id], len(sequence))-1]
+		_, _ = fmt.Fprintf(w, `{"id":%q,"status":%q}`, id, status)
+	}))
+	defer server.Close()
+
+	client := NewClient(ClientConfig{ApiKey: "test-api-key", BaseURL: server.URL})
+
+	t.Run("emits the status changes until the links are final", func(t *testing.T) {
+		store := NewMemoryLinkWatcherStore()
+		var errorsMu sync.Mutex
+		failures := map[string]error{}
+
+		watcher := NewLinkWatcher(client, LinkWatcherConfig{
+			Workers:     2,
+			RateLimit:   1000,
+			MinInterval: time.Millisecond,
+			Store:       store,
+			OnError: func(id string, err error) {
+				errorsMu.Lock()
+				defer errorsMu.Unlock()
+				failures[id] = err
+			},
+		})
+		require.NoError(t, watcher.Watch(context.Background(), "LNK_PAID", "LNK_REJECTED", "LNK_MISSING", "LNK_PAID"))
+		assert.Equal(t, 3, watcher.Len())
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		go func() {
+			_ = watcher.Run(ctx)
+		}()
+
+		transitions := map[string][]string{}
+		for change := range watcher.Events() {
+			transitions[change.PaymentLinkID] = append(transitions[change.PaymentLinkID], fmt.Sprintf("%s->%s", change.From, change.To))
+			if len(transitions["LNK_PAID"]) == 3 && len(transitions["LNK_REJECTED"]) == 1 {
+				break
+			}
+		}
+		cancel()
+
+		assert.Equal(t, []string{"->ACTIVE", "ACTIVE->PROCESSING", "PROCESSING->PAID"}, transitions["LNK_PAID"])
+		assert.Equal(t, []string{"->REJECTED"}, transitions["LNK_REJECTED"])
+
+		require.Eventually(t, func() bool { return watcher.Len() == 0 }, time.Second, time.Millisecond)
+		links, err := store.List(context.Background())
+		require.NoError(t, err)
+		assert.Empty(t, links)
+
+		errorsMu.Lock()
+		defer errorsMu.Unlock()
+		assert.ErrorIs(t, failures["LNK_MISSING"], ErrNotFound)
+	})
+
+	t.Run("resumes the links of the store", func(t *testing.T) {
+		store := NewMemoryLinkWatcherStore()
+		require.NoError(t, store.Save(context.Background(), WatchedLink{
+			ID:         "LNK_ANOMALY",
+			Status:     definitions.PaymentLinkStatusPaid,
+			AddedAt:    time.Now(),
+			NextPollAt: time.Now(),
+		}))
+
+		var anomaly error
+		var once sync.Once
+		watcher := NewLinkWatcher(client, LinkWatcherConfig{
+			RateLimit:   1000,
+			MinInterval: time.Millisecond,
+			Store:       store,
+			OnError: func(id string, err error) {
+				once.Do(func() { anomaly = err })
+			},
+		})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		go func() {
+			_ = watcher.Run(ctx)
+		}()
+
+		change := <-watcher.Events()
+		cancel()
+
+		assert.Equal(t, definitions.PaymentLinkStatusPaid, change.From)
+		assert.Equal(t, definitions.PaymentLinkStatusActive, change.To)
+		assert.ErrorIs(t, anomaly, definitions.ErrInvalidPaymentLinkTransition)
+	})
+
+	t.Run("compares with the last observed data", func(t *testing.T) {
+		store := NewMemoryLinkWatcherStore()
+		transactionID := "TX_0"
+		require.NoError(t, store.Save(context.Background(), WatchedLink{
+			ID:         "LNK_RETRANSACTED",
+			Status:     definitions.PaymentLinkStatusPaid,
+			AddedAt:    time.Now(),
+			NextPollAt: time.Now(),
+			Details:    &definitions.PaymentLinkDetails{ID: "LNK_RETRANSACTED", Status: definitions.PaymentLinkStatusPaid, TransactionID: &transactionID},
+		}))
+
+		anomalies := make(chan error, 1)
+		watcher := NewLinkWatcher(client, LinkWatcherConfig{
+			RateLimit:   1000,
+			MinInterval: time.Millisecond,
+			Store:       store,
+			OnError: func(id string, err error) {
+				anomalies <- err
+			},
+		})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		go func() {
+			_ = watcher.Run(ctx)
+		}()
+
+		// The status did not change, but the transaction of the paid link did
+		select {
+		case err := <-anomalies:
+			var transitionErr *definitions.PaymentLinkTransitionError
+			require.ErrorAs(t, err, &transitionErr)
+			assert.Equal(t, "the transaction of a paid payment link changed", transitionErr.Reason)
+		case <-ctx.Done():
+			t.Fatal("the changed transaction was not reported")
+		}
+		require.Eventually(t, func() bool { return watcher.Len() == 0 }, time.Second, time.Millisecond)
+	})
+
+	t.Run("links are saved before they are polled", func(t *testing.T) {
+		store := &failingLinkWatcherStore{MemoryLinkWatcherStore: NewMemoryLinkWatcherStore(), delay: 20 * time.Millisecond}
+		watcher := NewLinkWatcher(client, LinkWatcherConfig{RateLimit: 1000, MinInterval: time.Millisecond, Store: store})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		go func() {
+			_ = watcher.Run(ctx)
+		}()
+
+		require.NoError(t, watcher.Watch(ctx, "LNK_REJECTED"))
+		change := <-watcher.Events()
+		assert.Equal(t, definitions.PaymentLinkStatusRejected, change.To)
+
+		// The final status removes the link from the store after it was saved
+		require.Eventually(t, func() bool {
+			links, err := store.List(context.Background())
+			return err == nil && len(links) == 0
+		}, time.Second, time.Millisecond)
+	})
+
+	t.Run("links that cannot be saved are not watched", func(t *testing.T) {
+		store := &failingLinkWatcherStore{MemoryLinkWatcherStore: NewMemoryLinkWatcherStore(), err: errors.New("store unavailable")}
+		watcher := NewLinkWatcher(client, LinkWatcherConfig{Store: store})
+
+		err := watcher.Watch(context.Background(), "LNK_PAID", "LNK_REJECTED")
+
+		require.ErrorIs(t, err, store.err)
+		assert.Zero(t, watcher.Len())
+	})
+
+	t.Run("gives up after too many consecutive errors", func(t *testing.T) {
+		store := NewMemoryLinkWatcherStore()
+		var errorsMu sync.Mutex
+		var failures []error
+
+		watcher := NewLinkWatcher(client, LinkWatcherConfig{
+			RateLimit:   1000,
+			MinInterval: time.Millisecond,
+			MaxErrors:   2,
+			Store:       store,
+			OnError: func(id string, err error) {
+				errorsMu.Lock()
+				defer errorsMu.Unlock()
+				failures = append(failures, err)
+			},
+		})
+		require.NoError(t, watcher.Watch(context.Background(), "LNK_FAILING"))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		go func() {
+			_ = watcher.Run(ctx)
+		}()
+
+		require.Eventually(t, func() bool { return watcher.Len() == 0 }, time.Second, time.Millisecond)
+		cancel()
+
+		mu.Lock()
+		assert.Equal(t, 3, polls["LNK_FAILING"])
+		mu.Unlock()
+
+		errorsMu.Lock()
+		defer errorsMu.Unlock()
+		require.Len(t, failures, 3)
+		assert.ErrorIs(t, failures[0], ErrServer)
+		assert.NotErrorIs(t, failures[0], ErrTooManyPollErrors)
+		assert.ErrorIs(t, failures[2], ErrTooManyPollErrors)
+		assert.ErrorIs(t, failures[2], ErrServer)
+
+		links, err := store.List(context.Background())
+		require.NoError(t, err)
+		assert.Empty(t, links)
+	})
+
+	t.Run("links cannot be watched after the watcher stops", func(t *testing.T) {
+		watcher := NewLinkWatcher(client, LinkWatcherConfig{})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		require.ErrorIs(t, watcher.Run(ctx), context.Canceled)
+
+		err := watcher.Watch(context.Background(), "LNK_PAID")
+		require.ErrorIs(t, err, ErrWatcherStopped)
+		assert.Zero(t, watcher.Len())
+	})
+
+	t.Run("schedules the polls by age and expiration", func(t *testing.T) {
+		watcher := NewLinkWatcher(client, LinkWatcherConfig{MinInterval: time.Second, MaxInterval: time.Minute})
+		now := time.Now()
+
+		young := WatchedLink{AddedAt: now.Add(-time.Second)}
+		assert.Equal(t, now.Add(time.Second), watcher.schedule(young, now))
+
+		old := WatchedLink{AddedAt: now.Add(-5 * time.Minute)}
+		assert.Equal(t, now.Add(30*time.Second), watcher.schedule(old, now))
+
+		ancient := WatchedLink{AddedAt: now.Add(-24 * time.Hour)}
+		assert.Equal(t, now.Add(time.Minute), watcher.schedule(ancient, now))
+
+		expiring := WatchedLink{AddedAt: now.Add(-5 * time.Minute), ExpiresAt: now.Add(10 * time.Second)}
+		assert.Equal(t, expiring.ExpiresAt, watcher.schedule(expiring, now))
+	})
+
+	t.Run("the rate limit spaces the polls", func(t *testing.T) {
+		limiter := &pollRateLimiter{interval: 10 * time.Millisecond}
+
+		start := time.Now()
+		for range 5 {
+			require.NoError(t, limiter.wait(context.Background()))
+		}
+		assert.GreaterOrEqual(t, time.Since(start), 40*time.Millisecond)
+	})
+}
@@ -0,0 +1,132 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"time"
+
+	httpClient "github.com/PChaparro/bold-co-sdk/src/internal/http"
+	"github.com/PChaparro/bold-co-sdk/src/internal/redact"
+)
+
+// LogLevels contains the levels of the messages logged by the client.
+// The headers and the bodies of the requests are only logged at the debug level.
+type LogLevels struct {
+	// Success is the level of the requests answered with a successful status code.
+	Success slog.Level
+
+	// Retry is the level of the failed attempts that are going to be retried.
+	Retry slog.Level
+
+	// Failure is the level of the requests that failed or were answered with
+	// a non-successful status code.
+	Failure slog.Level
+}
+
+// DefaultLogLevels are the levels used when ClientConfig.LogLevels is not provided.
+var DefaultLogLevels = LogLevels{
+	Success: slog.LevelInfo,
+	Retry:   slog.LevelWarn,
+	Failure: slog.LevelError,
+}
+
+// redactedFields are the fields of the bodies that are never logged.
+var redactedFields = redact.Set(redact.Fields)
+
+// requestLogger logs the attempts and the result of a request sent to the Bold API.
+type requestLogger struct {
+	ctx    context.Context
+	logger *slog.Logger
+	levels LogLevels
+	req    *Request
+	start  time.Time
+}
+
+// newRequestLogger returns the logger of a request, or nil if the client has no logger.
+func (c *BoldClient) newRequestLogger(ctx context.Context, req *Request) *requestLogger {
+	if c.config.Logger == nil {
+		return nil
+	}
+
+	levels := DefaultLogLevels
+	if c.config.LogLevels != nil {
+		levels = *c.config.LogLevels
+	}
+
+	return &requestLogger{ctx: ctx, logger: c.config.Logger, levels: levels, req: req, start: time.Now()}
+}
+
+// afterAttempt logs the failed attempts that are going to be retried.
+// The last attempt is logged along with the result of the request.
+func (l *requestLogger) afterAttempt(attempt httpClient.Attempt) {
+	if !attempt.Retry {
+		return
+	}
+
+	attrs := append(l.attrs(), slog.Int("attempt", attempt.Number), slog.Duration("latency", attempt.Duration))
+	if attempt.StatusCode != 0 {
+		attrs = append(attrs, slog.Int("status", attempt.StatusCode))
+	}
+	if attempt.Err != nil {
+		attrs = append(attrs, slog.String("error", attempt.Err.Error()))
+	}
+
+	l.logger.LogAttrs(l.ctx, l.levels.Retry, "bold API request attempt failed, retrying", attrs...)
+}
+
+// done logs the result of the request.
+func (l *requestLogger) done(response *Response, err error) {
+	attrs := append(l.attrs(), slog.Duration("latency", time.Since(l.start)))
+
+	switch {
+	case err != nil:
+		var retryErr *httpClient.RetryError
+		if errors.As(err, &retryErr) {
+			attrs = append(attrs, slog.Int("attempts", retryErr.Attempts))
+		}
+		attrs = append(attrs, slog.String("error", err.Error()))
+		l.logger.LogAttrs(l.ctx, l.levels.Failure, "bold API request failed", attrs...)
+	case response.StatusCode < 200 || response.StatusCode >= 300:
+		attrs = append(attrs, slog.Int("status", response.StatusCode), slog.Int("attempts", response.Attempts))
+		l.logger.LogAttrs(l.ctx, l.levels.Failure, "bold API request failed", attrs...)
+	default:
+		attrs = append(attrs, slog.Int("status", response.StatusCode), slog.Int("attempts", response.Attempts))
+		l.logger.LogAttrs(l.ctx, l.levels.Success, "bold API request succeeded", attrs...)
+	}
+
+	l.debug(response)
+}
+
+// debug logs the headers and the bodies of the request and the response,
+// with the secrets and the personal data redacted.
+func (l *requestLogger) debug(response *Response) {
+	if !l.logger.Enabled(l.ctx, slog.LevelDebug) {
+		return
+	}
+
+	attrs := append(l.attrs(), slog.Any("request_headers", redact.Header(l.req.Headers, redact.Headers)))
+	if l.req.Body != nil {
+		if body, err := json.Marshal(l.req.Body); err == nil {
+			attrs = append(attrs, slog.String("request_body", redact.JSON(body, redactedFields)))
+		}
+	}
+	if response != nil {
+		attrs = append(attrs,
+			slog.Any("response_headers", redact.Header(response.Headers, redact.Headers)),
+			slog.String("response_body", redact.JSON(response.Body, redactedFields)),
+		)
+	}
+
+	l.logger.LogAttrs(l.ctx, slog.LevelDebug, "bold API request details", attrs...)
+}
+
+// attrs returns the attributes identifying the request.
+func (l *requestLogger) attrs() []slog.Attr {
+	return []slog.Attr{
+		slog.String("action", l.req.Action),
+		slog.String("method", l.req.Method),
+		slog.String("endpoint", l.req.Endpoint),
+	}
+}
@@ -0,0 +1,137 @@
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/PChaparro/bold-co-sdk/src/internal/tests"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// decodeLogs decodes the records written by a slog.JSONHandler.
+func decodeLogs(t *testing.T, output *bytes.Buffer) []map[string]any {
+	var records []map[string]any
+	decoder := json.NewDecoder(output)
+	for decoder.More() {
+		var record map[string]any
+		require.NoError(t, decoder.Decode(&record))
+		records = append(records, record)
+	}
+	return records
+}
+
+func TestLogging(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("requests are logged with their attempts", func(t *testing.T) {
+		server, _ := newFailingServer(t, 1, http.StatusBadGateway, nil)
+
+		var output bytes.Buffer
+		client := NewClient(ClientConfig{
+			ApiKey:      "secret-api-key",
+			BaseURL:     server.URL,
+			RetryPolicy: &RetryPolicy{MaxAttempts: 2, BaseDelay: time.Millisecond, RetryableStatusCodes: []int{http.StatusBadGateway}},
+			Logger:      slog.New(slog.NewJSONHandler(&output, nil)),
+		})
+
+		_, err := client.GetPaymentMethodsForPaymentLink(ctx)
+		require.NoError(t, err)
+
+		records := decodeLogs(t, &output)
+		require.Len(t, records, 2)
+
+		assert.Equal(t, "WARN", records[0]["level"])
+		assert.Equal(t, float64(1), records[0]["attempt"])
+		assert.Equal(t, float64(http.StatusBadGateway), records[0]["status"])
+
+		assert.Equal(t, "INFO", records[1]["level"])
+		assert.Equal(t, "get available payment methods for payment link", records[1]["action"])
+		assert.Equal(t, "/online/link/v1/payment_methods", records[1]["endpoint"])
+		assert.Equal(t, float64(http.StatusOK), records[1]["status"])
+		assert.Equal(t, float64(2), records[1]["attempts"])
+		assert.Contains(t, records[1], "latency")
+		assert.NotContains(t, output.String(), "secret-api-key")
+	})
+
+	t.Run("levels are configurable", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer server.Close()
+
+		var output bytes.Buffer
+		client := NewClient(ClientConfig{
+			ApiKey:    "test-api-key",
+			BaseURL:   server.URL,
+			Logger:    slog.New(slog.NewJSONHandler(&output, nil)),
+			LogLevels: &LogLevels{Success: slog.LevelDebug, Retry: slog.LevelDebug, Failure: slog.LevelWarn},
+		})
+
+		_, err := client.GetPaymentLinkData(ctx, "LNK_MISSING")
+		require.ErrorIs(t, err, ErrNotFound)
+
+		records := decodeLogs(t, &output)
+		require.Len(t, records, 1)
+		assert.Equal(t, "WARN", records[0]["level"])
+		assert.Equal(t, "bold API request failed", records[0]["msg"])
+	})
+
+	t.Run("bodies are only logged at debug, redacted", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"payload":{"integration_id":"INT_1"}}`))
+		}))
+		defer server.Close()
+
+		req := tests.GetPayloadToCreateValidPaymentForIntegrationsAPI()
+		for _, level := range []slog.Level{slog.LevelInfo, slog.LevelDebug} {
+			var output bytes.Buffer
+			client := NewClient(ClientConfig{
+				ApiKey:  "secret-api-key",
+				BaseURL: server.URL,
+				Logger:  slog.New(slog.NewJSONHandler(&output, &slog.HandlerOptions{Level: level})),
+			})
+
+			_, err := client.CreatePaymentForIntegrationsAPI(ctx, *req)
+			require.NoError(t, err)
+
+			logs := output.String()
+			assert.NotContains(t, logs, "secret-api-key")
+			assert.NotContains(t, logs, req.Payer.Email)
+			assert.NotContains(t, logs, req.Payer.PhoneNumber)
+			assert.NotContains(t, logs, req.Payer.Document.DocumentNumber)
+
+			if level == slog.LevelDebug {
+				assert.Contains(t, logs, "request_body")
+				assert.Contains(t, logs, req.Reference)
+				assert.Contains(t, logs, "INT_1")
+			} else {
+				assert.NotContains(t, logs, "request_body")
+			}
+		}
+	})
+
+	t.Run("connection errors are logged", func(t *testing.T) {
+		var output bytes.Buffer
+		client := NewClient(ClientConfig{
+			ApiKey:  "test-api-key",
+			BaseURL: "http://127.0.0.1:1",
+			Logger:  slog.New(slog.NewJSONHandler(&output, nil)),
+		})
+
+		_, err := client.GetPaymentMethodsForPaymentLink(ctx)
+		require.Error(t, err)
+
+		records := decodeLogs(t, &output)
+		require.Len(t, records, 1)
+		assert.Equal(t, "ERROR", records[0]["level"])
+		assert.Equal(t, float64(1), records[0]["attempts"])
+		assert.NotEmpty(t, records[0]["error"])
+	})
+}
@@ -0,0 +1,23 @@
+package sdk
+
+import "time"
+
+// Metrics receives the measurements of a BoldClient, so they can be exported
+// to a monitoring system. The boldprom module provides an implementation
+// backed by Prometheus. Implementations must be safe for concurrent use.
+//
+// The operations are identified by their action (e.g., "create payment link").
+type Metrics interface {
+	// ObserveRequest is called after every request sent to the Bold API, with
+	// the status code of its last response (0 if it failed without a response)
+	// and the duration of all its attempts.
+	ObserveRequest(operation string, statusCode int, duration time.Duration)
+
+	// ObserveRetry is called for every failed attempt that is going to be retried.
+	ObserveRetry(operation string)
+
+	// ObserveCacheLookup is called for every call to an operation whose
+	// response can be cached, when ClientConfig.Cache is set, reporting
+	// whether the response was served from the cache.
+	ObserveCacheLookup(operation string, hit bool)
+}
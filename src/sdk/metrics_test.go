@@ -0,0 +1,99 @@
+package sdk
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordedRequest is a request observed by the recordingMetrics.
+type recordedRequest struct {
+	operation  string
+	statusCode int
+}
+
+// recordingMetrics is a Metrics implementation that records the observations.
+type recordingMetrics struct {
+	mu       sync.Mutex
+	requests []recordedRequest
+	retries  []string
+	lookups  []bool
+}
+
+func (m *recordingMetrics) ObserveRequest(operation string, statusCode int, duration time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.requests = append(m.requests, recordedRequest{operation: operation, statusCode: statusCode})
+}
+
+func (m *recordingMetrics) ObserveRetry(operation string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.retries = append(m.retries, operation)
+}
+
+func (m *recordingMetrics) ObserveCacheLookup(operation string, hit bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.lookups = append(m.lookups, hit)
+}
+
+func TestMetrics(t *testing.T) {
+	ctx := context.Background()
+	operation := "get available payment methods for payment link"
+
+	t.Run("requests and retries", func(t *testing.T) {
+		server, _ := newFailingServer(t, 2, http.StatusBadGateway, nil)
+
+		metrics := &recordingMetrics{}
+		client := NewClient(ClientConfig{
+			ApiKey:      "test-api-key",
+			BaseURL:     server.URL,
+			RetryPolicy: &RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, RetryableStatusCodes: []int{http.StatusBadGateway}},
+			Metrics:     metrics,
+		})
+
+		_, err := client.GetPaymentMethodsForPaymentLink(ctx)
+		require.NoError(t, err)
+
+		assert.Equal(t, []recordedRequest{{operation: operation, statusCode: http.StatusOK}}, metrics.requests)
+		assert.Equal(t, []string{operation, operation}, metrics.retries)
+		assert.Empty(t, metrics.lookups, "the cache is not configured")
+	})
+
+	t.Run("failed requests", func(t *testing.T) {
+		metrics := &recordingMetrics{}
+		client := NewClient(ClientConfig{ApiKey: "test-api-key", BaseURL: "http://127.0.0.1:1", Metrics: metrics})
+
+		_, err := client.GetPaymentMethodsForPaymentLink(ctx)
+		require.Error(t, err)
+
+		assert.Equal(t, []recordedRequest{{operation: operation, statusCode: 0}}, metrics.requests)
+	})
+
+	t.Run("cache lookups", func(t *testing.T) {
+		server, calls := newFailingServer(t, 0, http.StatusOK, nil)
+
+		metrics := &recordingMetrics{}
+		client := NewClient(ClientConfig{
+			ApiKey:  "test-api-key",
+			BaseURL: server.URL,
+			Cache:   &CacheConfig{Cache: NewMemoryCache()},
+			Metrics: metrics,
+		})
+
+		for range 3 {
+			_, err := client.GetPaymentMethodsForPaymentLink(ctx)
+			require.NoError(t, err)
+		}
+
+		assert.Equal(t, int32(1), calls.Load())
+		assert.Equal(t, []bool{false, true, true}, metrics.lookups)
+		assert.Len(t, metrics.requests, 1, "cache hits are not sent")
+	})
+}
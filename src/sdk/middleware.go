@@ -0,0 +1,64 @@
+package sdk
+
+import (
+	"context"
+	"net/http"
+)
+
+// Request represents a request to the Bold API as seen by the middlewares.
+// Middlewares can modify it before passing it to the next handler.
+type Request struct {
+	// Action is the description of the action being performed (e.g., "create payment link").
+	Action string
+
+	// Method is the HTTP method of the request.
+	Method string
+
+	// Endpoint is the endpoint path, not including the base URL.
+	Endpoint string
+
+	// URL is the complete URL of the request.
+	URL string
+
+	// Headers to include in the request.
+	Headers http.Header
+
+	// Body to send with the request (nil for GET requests).
+	Body any
+}
+
+// Response represents a response from the Bold API as seen by the middlewares.
+// Non-successful responses are also passed through the middlewares before
+// being converted into an APIError.
+type Response struct {
+	// StatusCode is the HTTP status code.
+	StatusCode int
+
+	// Body is the raw response body.
+	Body []byte
+
+	// Headers from the response.
+	Headers http.Header
+
+	// Attempts is the number of attempts performed to get the response.
+	Attempts int
+}
+
+// Handler sends a request to the Bold API and returns its response.
+type Handler func(ctx context.Context, req *Request) (*Response, error)
+
+// Middleware wraps a Handler to run logic before and after every request
+// sent to the Bold API (e.g., adding headers, logging or collecting metrics).
+type Middleware func(next Handler) Handler
+
+// chainMiddlewares wraps the handler with the given middlewares. The first
+// middleware is the outermost one, so it is the first to see the request
+// and the last to see the response.
+func chainMiddlewares(handler Handler, middlewares []Middleware) Handler {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		if middlewares[i] != nil {
+			handler = middlewares[i](handler)
+		}
+	}
+	return handler
+}
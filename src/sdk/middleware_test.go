@@ -0,0 +1,127 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/PChaparro/bold-co-sdk/src/internal/tests"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMiddlewares(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Correlation-Id", r.Header.Get("X-Correlation-Id"))
+		if r.URL.Path == "/online/link/v1/LNK_MISSING" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{"payload":{"payment_link":"LNK_123","url":"https://checkout.bold.co/LNK_123"}}`))
+	}))
+	defer server.Close()
+
+	t.Run("middlewares run in order", func(t *testing.T) {
+		var events []string
+		newMiddleware := func(name string) Middleware {
+			return func(next Handler) Handler {
+				return func(ctx context.Context, req *Request) (*Response, error) {
+					events = append(events, name+" before "+req.Action)
+					response, err := next(ctx, req)
+					events = append(events, name+" after")
+					return response, err
+				}
+			}
+		}
+
+		client := NewClient(ClientConfig{
+			ApiKey:      "test-api-key",
+			BaseURL:     server.URL,
+			Middlewares: []Middleware{newMiddleware("first"), newMiddleware("second")},
+		})
+
+		_, err := client.CreatePaymentLink(context.Background(), *tests.GetPayloadToCreateValidPaymentLink())
+		require.NoError(t, err)
+		assert.Equal(t, []string{
+			"first before create payment link",
+			"second before create payment link",
+			"second after",
+			"first after",
+		}, events)
+	})
+
+	t.Run("middlewares can modify requests and observe responses", func(t *testing.T) {
+		var observed *Response
+		client := NewClient(ClientConfig{
+			ApiKey:  "test-api-key",
+			BaseURL: server.URL,
+			Middlewares: []Middleware{
+				func(next Handler) Handler {
+					return func(ctx context.Context, req *Request) (*Response, error) {
+						assert.Equal(t, http.MethodGet, req.Method)
+						assert.Equal(t, "/online/link/v1/LNK_MISSING", req.Endpoint)
+						assert.Equal(t, "x-api-key test-api-key", req.Headers.Get("Authorization"))
+
+						req.Headers.Set("X-Correlation-Id", "correlation-123")
+						response, err := next(ctx, req)
+						observed = response
+						return response, err
+					}
+				},
+			},
+		})
+
+		_, err := client.GetPaymentLinkData(context.Background(), "LNK_MISSING")
+		require.ErrorIs(t, err, ErrNotFound)
+		require.NotNil(t, observed)
+		assert.Equal(t, http.StatusNotFound, observed.StatusCode)
+		assert.Equal(t, "correlation-123", observed.Headers.Get("X-Correlation-Id"))
+	})
+
+	t.Run("middlewares can short-circuit requests", func(t *testing.T) {
+		errBlocked := errors.New("blocked by middleware")
+		client := NewClient(ClientConfig{
+			ApiKey:  "test-api-key",
+			BaseURL: server.URL,
+			Middlewares: []Middleware{
+				func(next Handler) Handler {
+					return func(ctx context.Context, req *Request) (*Response, error) {
+						return nil, errBlocked
+					}
+				},
+			},
+		})
+
+		_, err := client.GetPaymentMethodsForPaymentLink(context.Background())
+		require.ErrorIs(t, err, errBlocked)
+		assert.Contains(t, err.Error(), "failed to get available payment methods for payment link")
+	})
+
+	t.Run("nil responses are reported as errors", func(t *testing.T) {
+		client := NewClient(ClientConfig{
+			ApiKey:  "test-api-key",
+			BaseURL: server.URL,
+			Cache:   &CacheConfig{},
+			Middlewares: []Middleware{
+				func(next Handler) Handler {
+					return func(ctx context.Context, req *Request) (*Response, error) {
+						return nil, nil
+					}
+				},
+			},
+		})
+
+		_, err := client.GetPaymentLinkData(context.Background(), "LNK_123")
+		require.ErrorContains(t, err, "middleware returned a nil response")
+
+		var requestErr *RequestError
+		require.ErrorAs(t, err, &requestErr)
+		assert.Equal(t, "/online/link/v1/LNK_123", requestErr.Endpoint)
+
+		// Cacheable endpoints do not cache nil responses
+		_, err = client.GetPaymentMethodsForPaymentLink(context.Background())
+		require.ErrorContains(t, err, "middleware returned a nil response")
+	})
+}
@@ -0,0 +1,153 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/PChaparro/bold-co-sdk/src/definitions"
+)
+
+// DefaultPreflightTTL is how long the payment method limits are cached when
+// PreflightConfig.TTL is not provided.
+const DefaultPreflightTTL = 10 * time.Minute
+
+// PreflightMode defines what happens when a payment link requests payment
+// methods whose limits do not allow its total amount.
+type PreflightMode int
+
+const (
+	PreflightReject         PreflightMode = iota // Fail without creating the payment link (default).
+	PreflightDropIneligible                      // Remove the ineligible methods from the request.
+)
+
+// PreflightConfig contains the options to check the payment links against the
+// payment method limits returned by GetPaymentMethodsForPaymentLink before
+// creating them, so links that the payer could not pay are detected early.
+//
+// Only payment links with a CLOSE amount in COP are checked. Payment links
+// without payment methods are checked against every available method.
+type PreflightConfig struct {
+	// Mode defines what happens with the ineligible payment methods.
+	// If every requested method is ineligible, the creation always fails.
+	Mode PreflightMode
+
+	// TTL is how long the payment method limits are cached.
+	// If not provided, it defaults to DefaultPreflightTTL.
+	TTL time.Duration
+}
+
+// limitsCache caches the payment method limits used by the preflight.
+type limitsCache struct {
+	mu        sync.Mutex
+	methods   definitions.PaymentMethodsMap
+	expiresAt time.Time
+	timeout   time.Duration // Timeout of the shared fetches (0 for none).
+	flight    flightGroup[definitions.PaymentMethodsMap]
+}
+
+// get returns the cached limits, fetching them again when they expire.
+// Concurrent fetches are de-duplicated into a single one, which is not
+// cancelled when the caller that started it gives up.
+func (c *limitsCache) get(ctx context.Context, ttl time.Duration, fetch func(ctx context.Context) (definitions.PaymentMethodsMap, error)) (definitions.PaymentMethodsMap, error) {
+	c.mu.Lock()
+	methods, expiresAt := c.methods, c.expiresAt
+	c.mu.Unlock()
+
+	if methods != nil && time.Now().Before(expiresAt) {
+		return methods, nil
+	}
+
+	return c.flight.do(ctx, "limits", func() (definitions.PaymentMethodsMap, error) {
+		ctx, cancel := withTimeout(context.WithoutCancel(ctx), c.timeout)
+		defer cancel()
+
+		methods, err := fetch(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		if ttl <= 0 {
+			ttl = DefaultPreflightTTL
+		}
+
+		c.mu.Lock()
+		c.methods, c.expiresAt = methods, time.Now().Add(ttl)
+		c.mu.Unlock()
+		return methods, nil
+	})
+}
+
+// preflightPaymentLink checks the payment methods of the request against their
+// limits, returning the request to send. Ineligible methods are reported as a
+// *definitions.ValidationError wrapped in a RequestError.
+func (client *BoldClient) preflightPaymentLink(ctx context.Context, params RequestParams, req definitions.CreatePaymentLinkRequest) (definitions.CreatePaymentLinkRequest, error) {
+	preflight := client.config.Preflight
+	if preflight == nil || req.AmountType != definitions.AmountTypeClose || req.Amount == nil ||
+		req.Amount.Currency != definitions.CurrencyTypeCOP {
+		return req, nil
+	}
+
+	methods, err := client.limits.get(ctx, preflight.TTL, func(ctx context.Context) (definitions.PaymentMethodsMap, error) {
+		response, err := client.GetPaymentMethodsForPaymentLink(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return response.Payload.PaymentMethods, nil
+	})
+	if err != nil {
+		return req, fmt.Errorf("failed to check the payment method limits: %w", err)
+	}
+
+	total := req.Amount.TotalMoney()
+	eligible := methods.EligibleMethods(total)
+
+	if len(req.PaymentMethods) == 0 {
+		if len(eligible) == 0 {
+			return req, newPreflightError(params, definitions.FieldError{
+				Field:   "amount.total_amount",
+				Message: fmt.Sprintf("%s is not allowed by any payment method", total),
+			})
+		}
+		return req, nil
+	}
+
+	var allowed []definitions.PaymentMethod
+	var fieldErrors []definitions.FieldError
+	for i, method := range req.PaymentMethods {
+		if slices.Contains(eligible, method) {
+			allowed = append(allowed, method)
+			continue
+		}
+
+		message := fmt.Sprintf("%q is not available", method)
+		if limits, ok := methods[method]; ok {
+			message = fmt.Sprintf("%q does not allow %s (min: %d, max: %d)", method, total, limits.Min, limits.Max)
+		}
+		fieldErrors = append(fieldErrors, definitions.FieldError{
+			Field:   fmt.Sprintf("payment_methods[%d]", i),
+			Message: message,
+		})
+	}
+
+	if len(fieldErrors) == 0 {
+		return req, nil
+	}
+	if preflight.Mode != PreflightDropIneligible || len(allowed) == 0 {
+		return req, newPreflightError(params, fieldErrors...)
+	}
+
+	req.PaymentMethods = allowed
+	return req, nil
+}
+
+// newPreflightError builds the error returned when the preflight rejects a payment link.
+func newPreflightError(params RequestParams, fieldErrors ...definitions.FieldError) *RequestError {
+	return &RequestError{
+		Endpoint: params.Endpoint,
+		Action:   params.Action,
+		Err:      &definitions.ValidationError{Errors: fieldErrors},
+	}
+}
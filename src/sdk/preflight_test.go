@@ -0,0 +1,158 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/PChaparro/bold-co-sdk/src/definitions"
+	"github.com/PChaparro/bold-co-sdk/src/internal/tests"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPreflight(t *testing.T) {
+	var limitCalls atomic.Int32
+	var created atomic.Pointer[definitions.CreatePaymentLinkRequest]
+	var gate atomic.Pointer[chan struct{}]
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/online/link/v1/payment_methods":
+			limitCalls.Add(1)
+			if blocked := gate.Load(); blocked != nil {
+				<-*blocked
+			}
+			_, _ = w.Write([]byte(`{"payload":{"payment_methods":{
+				"PSE":{"min":1000,"max":5000},
+				"NEQUI":{"min":1000,"max":2000000},
+				"CREDIT_CARD":{"min":1000,"max":20000000}
+			}},"errors":[]}`))
+		case "/online/link/v1":
+			var req definitions.CreatePaymentLinkRequest
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			created.Store(&req)
+			_, _ = w.Write([]byte(`{"payload":{"payment_link":"LNK_TEST","url":"https://checkout.bold.co/LNK_TEST"},"errors":[]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	// The payload is a 10000 COP payment link
+	newRequest := func(methods ...definitions.PaymentMethod) definitions.CreatePaymentLinkRequest {
+		req := tests.GetPayloadToCreateValidPaymentLink()
+		req.PaymentMethods = methods
+		return *req
+	}
+
+	t.Run("ineligible methods are rejected", func(t *testing.T) {
+		created.Store(nil)
+		client := NewClient(ClientConfig{ApiKey: "test-api-key", BaseURL: server.URL, Preflight: &PreflightConfig{}})
+
+		response, err := client.CreatePaymentLink(context.Background(), newRequest(definitions.PaymentMethodNequi, definitions.PaymentMethodPse, definitions.PaymentMethodBotonBancolombia))
+
+		require.Error(t, err)
+		assert.Nil(t, response)
+		assert.Nil(t, created.Load())
+
+		var validationErr *definitions.ValidationError
+		require.ErrorAs(t, err, &validationErr)
+		assert.Len(t, validationErr.Errors, 2)
+		assert.True(t, validationErr.HasField("payment_methods[1]"))
+		assert.True(t, validationErr.HasField("payment_methods[2]"))
+	})
+
+	t.Run("ineligible methods are dropped", func(t *testing.T) {
+		client := NewClient(ClientConfig{ApiKey: "test-api-key", BaseURL: server.URL, Preflight: &PreflightConfig{Mode: PreflightDropIneligible}})
+
+		_, err := client.CreatePaymentLink(context.Background(), newRequest(definitions.PaymentMethodPse, definitions.PaymentMethodNequi))
+
+		require.NoError(t, err)
+		require.NotNil(t, created.Load())
+		assert.Equal(t, []definitions.PaymentMethod{definitions.PaymentMethodNequi}, created.Load().PaymentMethods)
+
+		_, err = client.CreatePaymentLink(context.Background(), newRequest(definitions.PaymentMethodPse))
+		assert.Error(t, err, "the creation fails when every method is ineligible")
+	})
+
+	t.Run("the limits are cached", func(t *testing.T) {
+		limitCalls.Store(0)
+		client := NewClient(ClientConfig{ApiKey: "test-api-key", BaseURL: server.URL, Preflight: &PreflightConfig{}})
+
+		for range 3 {
+			_, err := client.CreatePaymentLink(context.Background(), newRequest(definitions.PaymentMethodNequi))
+			require.NoError(t, err)
+		}
+		assert.Equal(t, int32(1), limitCalls.Load())
+	})
+
+	t.Run("slow fetches do not block other calls", func(t *testing.T) {
+		limitCalls.Store(0)
+		client := NewClient(ClientConfig{ApiKey: "test-api-key", BaseURL: server.URL, Preflight: &PreflightConfig{}})
+
+		blocked := make(chan struct{})
+		gate.Store(&blocked)
+		defer gate.Store(nil)
+
+		leaderErr := make(chan error, 1)
+		go func() {
+			_, err := client.CreatePaymentLink(context.Background(), newRequest(definitions.PaymentMethodNequi))
+			leaderErr <- err
+		}()
+		require.Eventually(t, func() bool { return limitCalls.Load() == 1 }, time.Second, time.Millisecond)
+
+		// Other calls give up when their context is done, while the fetch is in flight
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		start := time.Now()
+		_, err := client.CreatePaymentLink(ctx, newRequest(definitions.PaymentMethodNequi))
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Less(t, time.Since(start), 500*time.Millisecond)
+
+		close(blocked)
+		require.NoError(t, <-leaderErr)
+		assert.Equal(t, int32(1), limitCalls.Load())
+	})
+
+	t.Run("amounts in other currencies are not checked", func(t *testing.T) {
+		limitCalls.Store(0)
+		client := NewClient(ClientConfig{ApiKey: "test-api-key", BaseURL: server.URL, Preflight: &PreflightConfig{}})
+
+		req := newRequest(definitions.PaymentMethodPse)
+		req.Amount.Currency = definitions.CurrencyTypeUSD
+		_, err := client.CreatePaymentLink(context.Background(), req)
+
+		require.NoError(t, err)
+		assert.Zero(t, limitCalls.Load())
+	})
+
+	t.Run("open amounts are not checked", func(t *testing.T) {
+		limitCalls.Store(0)
+		created.Store(nil)
+		client := NewClient(ClientConfig{ApiKey: "test-api-key", BaseURL: server.URL, Preflight: &PreflightConfig{Mode: PreflightDropIneligible}})
+
+		req := newRequest(definitions.PaymentMethodPse, definitions.PaymentMethodNequi)
+		req.AmountType = definitions.AmountTypeOpen
+		_, err := client.CreatePaymentLink(context.Background(), req)
+
+		require.NoError(t, err)
+		assert.Zero(t, limitCalls.Load())
+		require.NotNil(t, created.Load())
+		assert.Equal(t, []definitions.PaymentMethod{definitions.PaymentMethodPse, definitions.PaymentMethodNequi}, created.Load().PaymentMethods)
+	})
+
+	t.Run("the preflight is disabled by default", func(t *testing.T) {
+		limitCalls.Store(0)
+		client := NewClient(ClientConfig{ApiKey: "test-api-key", BaseURL: server.URL})
+
+		_, err := client.CreatePaymentLink(context.Background(), newRequest(definitions.PaymentMethodPse))
+
+		require.NoError(t, err)
+		assert.Zero(t, limitCalls.Load())
+	})
+}
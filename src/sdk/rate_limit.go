@@ -0,0 +1,198 @@
+package sdk
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	httpClient "github.com/PChaparro/bold-co-sdk/src/internal/http"
+)
+
+// DefaultRateLimitPause is how long the rate limiter stops sending requests
+// after Bold responds with HTTP 429 without a Retry-After header.
+const DefaultRateLimitPause = time.Second
+
+// RateLimit contains the options of a token bucket.
+type RateLimit struct {
+	// RequestsPerSecond is the sustained rate of requests.
+	RequestsPerSecond float64
+
+	// Burst is the number of requests that can be sent at once after a period
+	// of inactivity. Values lower than 1 default to 1.
+	Burst int
+}
+
+// RateLimitConfig contains the options of the client-side rate limiter.
+// Every attempt to send a request, including the retries, waits until the
+// global limit and the limit of its endpoint group allow it, or until the
+// context is done. Every limit is optional.
+//
+// When Bold responds with HTTP 429, the limits of the request stop sending
+// requests for the time given by the Retry-After header (DefaultRateLimitPause
+// if not provided).
+type RateLimitConfig struct {
+	// Global limits every request sent by the client.
+	Global *RateLimit
+
+	// PaymentLinks limits the requests sent to the payment links API.
+	PaymentLinks *RateLimit
+
+	// Integrations limits the requests sent to the integrations API.
+	Integrations *RateLimit
+}
+
+// rateLimiter holds the token buckets of a client.
+type rateLimiter struct {
+	global       *tokenBucket
+	paymentLinks *tokenBucket
+	integrations *tokenBucket
+}
+
+// newRateLimiter builds the rate limiter of the client, or nil if it is not configured.
+func newRateLimiter(config ClientConfig) *rateLimiter {
+	if config.RateLimit == nil {
+		return nil
+	}
+
+	return &rateLimiter{
+		global:       newTokenBucket(config.RateLimit.Global),
+		paymentLinks: newTokenBucket(config.RateLimit.PaymentLinks),
+		integrations: newTokenBucket(config.RateLimit.Integrations),
+	}
+}
+
+// buckets returns the token buckets limiting the requests to the given endpoint.
+func (l *rateLimiter) buckets(endpoint string) []*tokenBucket {
+	buckets := make([]*tokenBucket, 0, 2)
+	if l.global != nil {
+		buckets = append(buckets, l.global)
+	}
+
+	switch {
+	case strings.HasPrefix(endpoint, "/online/link/"):
+		if l.paymentLinks != nil {
+			buckets = append(buckets, l.paymentLinks)
+		}
+	case strings.HasPrefix(endpoint, "/payments/"):
+		if l.integrations != nil {
+			buckets = append(buckets, l.integrations)
+		}
+	}
+
+	return buckets
+}
+
+// wait blocks until every bucket of the endpoint allows a request, or until
+// the context is done.
+func (l *rateLimiter) wait(ctx context.Context, endpoint string) error {
+	buckets := l.buckets(endpoint)
+
+	now := time.Now()
+	var delay time.Duration
+	reserved := make([]time.Time, len(buckets))
+	for i, bucket := range buckets {
+		var bucketDelay time.Duration
+		bucketDelay, reserved[i] = bucket.reserve(now)
+		delay = max(delay, bucketDelay)
+	}
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		// Give back the reservations, since the request is not sent
+		for i, bucket := range buckets {
+			bucket.cancel(reserved[i])
+		}
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// observe pauses the buckets of the endpoint when Bold rejects a request
+// because of its rate limits.
+func (l *rateLimiter) observe(endpoint string, attempt httpClient.Attempt) {
+	if attempt.StatusCode != http.StatusTooManyRequests {
+		return
+	}
+
+	pause, ok := httpClient.ParseRetryAfter(attempt.Headers)
+	if !ok {
+		pause = DefaultRateLimitPause
+	}
+
+	until := time.Now().Add(pause)
+	for _, bucket := range l.buckets(endpoint) {
+		bucket.pause(until)
+	}
+}
+
+// tokenBucket is a token bucket implemented as a generic cell rate algorithm:
+// instead of counting tokens, it tracks the time when the bucket will be
+// full again, spacing the requests evenly once the burst is spent.
+type tokenBucket struct {
+	mu        sync.Mutex
+	interval  time.Duration // Time to get a new token.
+	tolerance time.Duration // Time to fill the burst, minus one token.
+	full      time.Time     // Theoretical time when the bucket is full again.
+}
+
+// newTokenBucket builds a token bucket, or nil if the limit is not configured.
+func newTokenBucket(limit *RateLimit) *tokenBucket {
+	if limit == nil || limit.RequestsPerSecond <= 0 {
+		return nil
+	}
+
+	burst := max(limit.Burst, 1)
+	interval := time.Duration(float64(time.Second) / limit.RequestsPerSecond)
+
+	return &tokenBucket{
+		interval:  interval,
+		tolerance: time.Duration(burst-1) * interval,
+	}
+}
+
+// reserve takes a token, returning how long to wait before using it and the
+// time when the bucket is full again after the reservation, used to cancel it.
+func (b *tokenBucket) reserve(now time.Time) (time.Duration, time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	full := b.full
+	if full.Before(now) {
+		full = now
+	}
+
+	allowedAt := full.Add(-b.tolerance)
+	b.full = full.Add(b.interval)
+
+	return allowedAt.Sub(now), b.full
+}
+
+// cancel gives back a reserved token, given the time returned by reserve.
+// The token is only given back if no other token was reserved (and the bucket
+// was not paused) since, as the later reservations already wait for it.
+func (b *tokenBucket) cancel(reserved time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.full.Equal(reserved) {
+		b.full = reserved.Add(-b.interval)
+	}
+}
+
+// pause empties the bucket and stops giving tokens until the given time.
+func (b *tokenBucket) pause(until time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if paused := until.Add(b.tolerance); paused.After(b.full) {
+		b.full = paused
+	}
+}
@@ -0,0 +1,194 @@
+package sdk
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/PChaparro/bold-co-sdk/src/boldtest"
+	"github.com/PChaparro/bold-co-sdk/src/internal/tests"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenBucket(t *testing.T) {
+	now := time.Now()
+
+	// delay reserves a token, returning how long to wait before using it
+	delay := func(bucket *tokenBucket, now time.Time) time.Duration {
+		delay, _ := bucket.reserve(now)
+		return delay
+	}
+
+	t.Run("burst and sustained rate", func(t *testing.T) {
+		bucket := newTokenBucket(&RateLimit{RequestsPerSecond: 10, Burst: 3})
+
+		assert.LessOrEqual(t, delay(bucket, now), time.Duration(0))
+		assert.LessOrEqual(t, delay(bucket, now), time.Duration(0))
+		assert.LessOrEqual(t, delay(bucket, now), time.Duration(0))
+		assert.Equal(t, 100*time.Millisecond, delay(bucket, now))
+		assert.Equal(t, 200*time.Millisecond, delay(bucket, now))
+
+		// Tokens are refilled over time
+		assert.LessOrEqual(t, delay(bucket, now.Add(time.Second)), time.Duration(0))
+	})
+
+	t.Run("cancelled reservations are given back", func(t *testing.T) {
+		bucket := newTokenBucket(&RateLimit{RequestsPerSecond: 10})
+
+		bucket.reserve(now)
+		wait, reserved := bucket.reserve(now)
+		assert.Equal(t, 100*time.Millisecond, wait)
+		bucket.cancel(reserved)
+		assert.Equal(t, 100*time.Millisecond, delay(bucket, now))
+	})
+
+	t.Run("reservations followed by others are not given back", func(t *testing.T) {
+		bucket := newTokenBucket(&RateLimit{RequestsPerSecond: 10})
+
+		bucket.reserve(now)
+		_, reserved := bucket.reserve(now)
+		assert.Equal(t, 200*time.Millisecond, delay(bucket, now))
+		bucket.cancel(reserved)
+		assert.Equal(t, 300*time.Millisecond, delay(bucket, now), "the token is still taken by the later reservation")
+
+		_, reserved = bucket.reserve(now)
+		bucket.pause(now.Add(time.Second))
+		bucket.cancel(reserved)
+		assert.Equal(t, time.Second, delay(bucket, now), "the pause is kept")
+	})
+
+	t.Run("pause", func(t *testing.T) {
+		bucket := newTokenBucket(&RateLimit{RequestsPerSecond: 10, Burst: 5})
+
+		bucket.pause(now.Add(time.Second))
+		assert.Equal(t, time.Second, delay(bucket, now))
+		assert.Equal(t, 1100*time.Millisecond, delay(bucket, now), "the burst is not available after the pause")
+	})
+
+	t.Run("not configured", func(t *testing.T) {
+		assert.Nil(t, newTokenBucket(nil))
+		assert.Nil(t, newTokenBucket(&RateLimit{}))
+	})
+}
+
+func TestRateLimit(t *testing.T) {
+	ctx := context.Background()
+
+	server := boldtest.NewServer(boldtest.Config{
+		Terminals: []boldtest.Terminal{{Model: "N86", Serial: "N860W000000"}},
+	})
+	defer server.Close()
+
+	newClient := func(config RateLimitConfig) *BoldClient {
+		return NewClient(ClientConfig{ApiKey: boldtest.DefaultAPIKey, BaseURL: server.URL, RateLimit: &config})
+	}
+
+	t.Run("requests are spaced", func(t *testing.T) {
+		client := newClient(RateLimitConfig{Global: &RateLimit{RequestsPerSecond: 20, Burst: 2}})
+
+		start := time.Now()
+		for range 4 {
+			_, err := client.GetPaymentMethodsForPaymentLink(ctx)
+			require.NoError(t, err)
+		}
+		assert.GreaterOrEqual(t, time.Since(start), 90*time.Millisecond)
+	})
+
+	t.Run("waiting respects the context", func(t *testing.T) {
+		client := newClient(RateLimitConfig{PaymentLinks: &RateLimit{RequestsPerSecond: 0.1}})
+
+		_, err := client.CreatePaymentLink(ctx, *tests.GetPayloadToCreateValidPaymentLink())
+		require.NoError(t, err)
+
+		requests := len(server.Requests())
+		timeoutCtx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+		defer cancel()
+
+		_, err = client.CreatePaymentLink(timeoutCtx, *tests.GetPayloadToCreateValidPaymentLink())
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+
+		var requestErr *RequestError
+		require.ErrorAs(t, err, &requestErr)
+		assert.Equal(t, 0, requestErr.Attempts, "the request is not sent")
+		assert.Len(t, server.Requests(), requests)
+
+		// The integrations API is not limited by the payment links limit
+		_, err = client.GetBindedTerminalsForIntegrationsAPI(ctx, WithTimeout(20*time.Millisecond))
+		assert.NoError(t, err)
+	})
+
+	t.Run("cancelled waits do not raise the rate", func(t *testing.T) {
+		const interval = 10 * time.Millisecond
+		limiter := newRateLimiter(ClientConfig{RateLimit: &RateLimitConfig{Global: &RateLimit{RequestsPerSecond: float64(time.Second / interval)}}})
+
+		var mu sync.Mutex
+		var allowed []time.Time
+		wait := func(ctx context.Context) {
+			if limiter.wait(ctx, "/online/link/v1") == nil {
+				mu.Lock()
+				defer mu.Unlock()
+				allowed = append(allowed, time.Now())
+			}
+		}
+
+		var wg sync.WaitGroup
+		launch := func(requests int, timeout time.Duration) {
+			for range requests {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					timeoutCtx, cancel := context.WithTimeout(ctx, timeout)
+					defer cancel()
+					wait(timeoutCtx)
+				}()
+			}
+			// Let the requests reserve their tokens before the next ones
+			time.Sleep(time.Millisecond)
+		}
+
+		// The requests in the middle give up while the ones after them are waiting
+		launch(10, time.Minute)
+		launch(10, 3*interval)
+		launch(10, time.Minute)
+
+		// The next requests must not take the tokens of the pending ones
+		time.Sleep(5 * interval)
+		launch(10, time.Minute)
+		wg.Wait()
+
+		slices.SortFunc(allowed, func(a, b time.Time) int { return a.Compare(b) })
+		require.Len(t, allowed, 30)
+		// Every 10 requests take at least 10 intervals, minus some slack for the timers
+		for i := 10; i < len(allowed); i++ {
+			assert.Greater(t, allowed[i].Sub(allowed[i-10]), 6*interval, "requests %d to %d were sent above the rate", i-10, i)
+		}
+	})
+
+	t.Run("rate limited responses pause the requests", func(t *testing.T) {
+		client := newClient(RateLimitConfig{Integrations: &RateLimit{RequestsPerSecond: 1000}})
+
+		server.InjectFault(boldtest.Fault{
+			Path:       "/payments/payment-methods",
+			StatusCode: http.StatusTooManyRequests,
+			Headers:    map[string]string{"Retry-After": "1"},
+			Times:      1,
+		})
+		_, err := client.GetPaymentMethodsForIntegrationsAPI(ctx)
+		require.ErrorIs(t, err, ErrRateLimited)
+
+		start := time.Now()
+		_, err = client.GetBindedTerminalsForIntegrationsAPI(ctx)
+		require.NoError(t, err)
+		assert.GreaterOrEqual(t, time.Since(start), 900*time.Millisecond)
+
+		// Other endpoint groups are not paused
+		start = time.Now()
+		_, err = client.GetPaymentMethodsForPaymentLink(ctx)
+		require.NoError(t, err)
+		assert.Less(t, time.Since(start), 500*time.Millisecond)
+	})
+}
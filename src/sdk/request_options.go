@@ -0,0 +1,40 @@
+package sdk
+
+import "time"
+
+// RequestOption configures a single call to a BoldClient method.
+type RequestOption func(*requestOptions)
+
+// requestOptions contains the options that can be configured per call.
+type requestOptions struct {
+	timeout     time.Duration
+	bypassCache bool
+}
+
+// WithTimeout sets a timeout for a single call, including all its retries.
+// The timeout is applied through the request context, so the HTTP client
+// and transport of the BoldClient are preserved.
+func WithTimeout(timeout time.Duration) RequestOption {
+	return func(o *requestOptions) {
+		o.timeout = timeout
+	}
+}
+
+// WithCacheBypass fetches the response from the Bold API even if it is cached,
+// refreshing the cached response. It has no effect on the endpoints that are not cached.
+func WithCacheBypass() RequestOption {
+	return func(o *requestOptions) {
+		o.bypassCache = true
+	}
+}
+
+// newRequestOptions applies the given options over the default ones.
+func newRequestOptions(opts []RequestOption) requestOptions {
+	var options requestOptions
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&options)
+		}
+	}
+	return options
+}
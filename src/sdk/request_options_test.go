@@ -0,0 +1,59 @@
+package sdk
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+		_, _ = w.Write([]byte(`{"payload":{}}`))
+	}))
+	defer server.Close()
+
+	// Count the requests sent through the configured transport
+	var calls atomic.Int32
+	transport := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		calls.Add(1)
+		return http.DefaultTransport.RoundTrip(req)
+	})
+
+	client := NewClient(ClientConfig{
+		ApiKey:    "test-api-key",
+		BaseURL:   server.URL,
+		Transport: transport,
+	})
+
+	t.Run("the call fails when the timeout expires", func(t *testing.T) {
+		start := time.Now()
+		response, err := client.GetBindedTerminalsForIntegrationsAPI(context.Background(), WithTimeout(50*time.Millisecond))
+
+		require.Error(t, err)
+		assert.Nil(t, response)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Less(t, time.Since(start), time.Second)
+
+		var requestErr *RequestError
+		require.ErrorAs(t, err, &requestErr)
+		assert.Equal(t, "get binded terminals for integrations API", requestErr.Action)
+	})
+
+	t.Run("the configured transport is preserved", func(t *testing.T) {
+		calls.Store(0)
+
+		_, err := client.GetPaymentLinkData(context.Background(), "LNK_TEST", WithTimeout(10*time.Millisecond))
+		require.Error(t, err)
+		assert.Equal(t, int32(1), calls.Load())
+	})
+}
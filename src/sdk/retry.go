@@ -0,0 +1,70 @@
+package sdk
+
+import (
+	"net/http"
+	"time"
+
+	httpClient "github.com/PChaparro/bold-co-sdk/src/internal/http"
+)
+
+// RetryPolicy contains the options to retry the requests sent to the Bold API
+// when they fail due to transient errors.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts, including the first one.
+	// Values lower than 2 disable retries.
+	MaxAttempts int
+
+	// BaseDelay is the delay before the first retry. It doubles on every subsequent retry.
+	BaseDelay time.Duration
+
+	// MaxDelay caps the delay between two attempts, including the delays
+	// requested by Bold through the Retry-After header.
+	MaxDelay time.Duration
+
+	// Jitter is the fraction (between 0 and 1) of the delay that is randomized.
+	Jitter float64
+
+	// RetryableStatusCodes are the response status codes that trigger a retry.
+	RetryableStatusCodes []int
+
+	// RetryNonIdempotent allows retrying POST requests (e.g., CreatePaymentLink)
+	// after they may have reached Bold, which could create duplicated resources.
+	// When disabled, POST requests are only retried when they were never sent
+	// or when Bold rejected them with HTTP 429.
+	RetryNonIdempotent bool
+}
+
+// DefaultRetryPolicy returns a retry policy with sensible defaults: up to 3 attempts
+// with an exponential backoff starting at 200ms and capped at 5s, retrying
+// timeouts, rate limits and gateway errors.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts: 3,
+		BaseDelay:   200 * time.Millisecond,
+		MaxDelay:    5 * time.Second,
+		Jitter:      0.2,
+		RetryableStatusCodes: []int{
+			http.StatusRequestTimeout,
+			http.StatusTooManyRequests,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+	}
+}
+
+// toInternal converts the retry policy to the one used by the internal HTTP client.
+func (p *RetryPolicy) toInternal() *httpClient.RetryPolicy {
+	if p == nil {
+		return nil
+	}
+
+	return &httpClient.RetryPolicy{
+		MaxAttempts:          p.MaxAttempts,
+		BaseDelay:            p.BaseDelay,
+		MaxDelay:             p.MaxDelay,
+		Jitter:               p.Jitter,
+		RetryableStatusCodes: p.RetryableStatusCodes,
+		RetryNonIdempotent:   p.RetryNonIdempotent,
+	}
+}
@@ -0,0 +1,137 @@
+package sdk
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/PChaparro/bold-co-sdk/src/internal/tests"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newFailingServer returns a test server that responds with the given status code
+// to the first failures requests and with a valid payment methods response afterwards.
+func newFailingServer(t *testing.T, failures int32, statusCode int, headers map[string]string) (*httptest.Server, *atomic.Int32) {
+	var calls atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) <= failures {
+			for key, value := range headers {
+				w.Header().Set(key, value)
+			}
+			w.WriteHeader(statusCode)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"payload":{"payment_methods":{"PSE":{"min":1000,"max":100000}}},"errors":[]}`))
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &calls
+}
+
+func TestRetryPolicy(t *testing.T) {
+	policy := &RetryPolicy{
+		MaxAttempts:          3,
+		BaseDelay:            time.Millisecond,
+		MaxDelay:             10 * time.Millisecond,
+		Jitter:               0.5,
+		RetryableStatusCodes: []int{http.StatusTooManyRequests, http.StatusBadGateway},
+	}
+
+	t.Run("GET is retried until it succeeds", func(t *testing.T) {
+		server, calls := newFailingServer(t, 2, http.StatusBadGateway, nil)
+
+		client := NewClient(ClientConfig{ApiKey: "test-api-key", BaseURL: server.URL, RetryPolicy: policy})
+		response, err := client.GetPaymentMethodsForPaymentLink(context.Background())
+
+		require.NoError(t, err)
+		require.NotNil(t, response)
+		assert.Equal(t, int32(3), calls.Load())
+	})
+
+	t.Run("GET gives up after max attempts", func(t *testing.T) {
+		server, calls := newFailingServer(t, 5, http.StatusBadGateway, nil)
+
+		client := NewClient(ClientConfig{ApiKey: "test-api-key", BaseURL: server.URL, RetryPolicy: policy})
+		_, err := client.GetPaymentMethodsForPaymentLink(context.Background())
+
+		require.ErrorIs(t, err, ErrServer)
+		var apiErr *APIError
+		require.ErrorAs(t, err, &apiErr)
+		assert.Equal(t, 3, apiErr.Attempts)
+		assert.Equal(t, int32(3), calls.Load())
+	})
+
+	t.Run("non retryable status code", func(t *testing.T) {
+		server, calls := newFailingServer(t, 1, http.StatusInternalServerError, nil)
+
+		client := NewClient(ClientConfig{ApiKey: "test-api-key", BaseURL: server.URL, RetryPolicy: policy})
+		_, err := client.GetPaymentMethodsForPaymentLink(context.Background())
+
+		require.ErrorIs(t, err, ErrServer)
+		assert.Equal(t, int32(1), calls.Load())
+	})
+
+	t.Run("POST is not retried after reaching the server", func(t *testing.T) {
+		server, calls := newFailingServer(t, 1, http.StatusBadGateway, nil)
+
+		client := NewClient(ClientConfig{ApiKey: "test-api-key", BaseURL: server.URL, RetryPolicy: policy})
+		_, err := client.CreatePaymentLink(context.Background(), *tests.GetPayloadToCreateValidPaymentLink())
+
+		require.ErrorIs(t, err, ErrServer)
+		assert.Equal(t, int32(1), calls.Load())
+	})
+
+	t.Run("POST is retried when rate limited honoring Retry-After", func(t *testing.T) {
+		server, calls := newFailingServer(t, 1, http.StatusTooManyRequests, map[string]string{"Retry-After": "0"})
+
+		client := NewClient(ClientConfig{ApiKey: "test-api-key", BaseURL: server.URL, RetryPolicy: policy})
+		_, err := client.CreatePaymentLink(context.Background(), *tests.GetPayloadToCreateValidPaymentLink())
+
+		require.NoError(t, err)
+		assert.Equal(t, int32(2), calls.Load())
+	})
+
+	t.Run("POST is retried when non idempotent retries are allowed", func(t *testing.T) {
+		server, calls := newFailingServer(t, 1, http.StatusBadGateway, nil)
+
+		unsafePolicy := *policy
+		unsafePolicy.RetryNonIdempotent = true
+
+		client := NewClient(ClientConfig{ApiKey: "test-api-key", BaseURL: server.URL, RetryPolicy: &unsafePolicy})
+		_, err := client.CreatePaymentLink(context.Background(), *tests.GetPayloadToCreateValidPaymentLink())
+
+		require.NoError(t, err)
+		assert.Equal(t, int32(2), calls.Load())
+	})
+
+	t.Run("connection errors are retried", func(t *testing.T) {
+		// Close the server right away so the connection is refused
+		server := httptest.NewServer(http.NotFoundHandler())
+		server.Close()
+
+		client := NewClient(ClientConfig{ApiKey: "test-api-key", BaseURL: server.URL, RetryPolicy: policy})
+		_, err := client.CreatePaymentLink(context.Background(), *tests.GetPayloadToCreateValidPaymentLink())
+
+		var requestErr *RequestError
+		require.ErrorAs(t, err, &requestErr)
+		assert.Equal(t, 3, requestErr.Attempts)
+		assert.Equal(t, "create payment link", requestErr.Action)
+	})
+
+	t.Run("without retry policy", func(t *testing.T) {
+		server, calls := newFailingServer(t, 1, http.StatusBadGateway, nil)
+
+		client := NewClient(ClientConfig{ApiKey: "test-api-key", BaseURL: server.URL})
+		_, err := client.GetPaymentMethodsForPaymentLink(context.Background())
+
+		require.ErrorIs(t, err, ErrServer)
+		assert.Equal(t, int32(1), calls.Load())
+	})
+}
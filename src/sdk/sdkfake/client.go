@@ -0,0 +1,268 @@
+// Package sdkfake provides an in-memory implementation of the sdk.BoldAPI
+// interface, so services depending on it can be unit tested without a server.
+//
+// By default, the fake Client behaves like Bold: it validates the requests,
+// keeps the created payment links in memory and returns the same errors as
+// the real client (e.g., matching sdk.ErrNotFound). Every operation can be
+// overridden with a function, and every call is recorded.
+package sdkfake
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/PChaparro/bold-co-sdk/src/definitions"
+	"github.com/PChaparro/bold-co-sdk/src/internal/fixtures"
+	"github.com/PChaparro/bold-co-sdk/src/sdk"
+)
+
+// Names of the operations, used in the recorded calls.
+const (
+	OperationCreatePaymentLink                    = "CreatePaymentLink"
+	OperationGetPaymentLinkData                   = "GetPaymentLinkData"
+	OperationGetPaymentMethodsForPaymentLink      = "GetPaymentMethodsForPaymentLink"
+	OperationGetPaymentMethodsForIntegrationsAPI  = "GetPaymentMethodsForIntegrationsAPI"
+	OperationGetBindedTerminalsForIntegrationsAPI = "GetBindedTerminalsForIntegrationsAPI"
+	OperationCreatePaymentForIntegrationsAPI      = "CreatePaymentForIntegrationsAPI"
+)
+
+// Call is a call received by the fake Client.
+type Call struct {
+	// Operation is the name of the called operation (e.g., OperationCreatePaymentLink).
+	Operation string
+
+	// Request is the request of the call: a CreatePaymentLinkRequest, a
+	// CreatePaymentForIntegrationsAPIRequest, the payment link ID of
+	// GetPaymentLinkData, or nil for the operations without arguments.
+	Request any
+
+	// Time is when the call was received.
+	Time time.Time
+}
+
+// Client is a fake implementation of sdk.BoldAPI. The function fields
+// override the default behavior of the operations; they must be set before
+// the client is used.
+type Client struct {
+	// CreatePaymentLinkFunc overrides CreatePaymentLink.
+	CreatePaymentLinkFunc func(ctx context.Context, req definitions.CreatePaymentLinkRequest) (*definitions.CreatePaymentLinkResponse, error)
+
+	// GetPaymentLinkDataFunc overrides GetPaymentLinkData.
+	GetPaymentLinkDataFunc func(ctx context.Context, paymentLinkId string) (*definitions.GetPaymentLinkDataResponse, error)
+
+	// GetPaymentMethodsForPaymentLinkFunc overrides GetPaymentMethodsForPaymentLink.
+	GetPaymentMethodsForPaymentLinkFunc func(ctx context.Context) (*definitions.GetPaymentMethodsForPaymentLinkResponse, error)
+
+	// GetPaymentMethodsForIntegrationsAPIFunc overrides GetPaymentMethodsForIntegrationsAPI.
+	GetPaymentMethodsForIntegrationsAPIFunc func(ctx context.Context) (*definitions.GetPaymentMethodsForIntegrationsAPIResponse, error)
+
+	// GetBindedTerminalsForIntegrationsAPIFunc overrides GetBindedTerminalsForIntegrationsAPI.
+	GetBindedTerminalsForIntegrationsAPIFunc func(ctx context.Context) (*definitions.GetBindedTerminalsForIntegrationsAPIResponse, error)
+
+	// CreatePaymentForIntegrationsAPIFunc overrides CreatePaymentForIntegrationsAPI.
+	CreatePaymentForIntegrationsAPIFunc func(ctx context.Context, req definitions.CreatePaymentForIntegrationsAPIRequest) (*definitions.CreatePaymentForIntegrationsAPIResponse, error)
+
+	// Terminals are the terminals returned by default by GetBindedTerminalsForIntegrationsAPI.
+	// Like Bold, it fails with sdk.ErrNotFound when there are none.
+	Terminals []definitions.TerminalInfo
+
+	mu       sync.Mutex
+	calls    []Call
+	links    map[string]*definitions.PaymentLinkDetails
+	sequence int
+}
+
+// Verify that Client implements the sdk.BoldAPI interface.
+var _ sdk.BoldAPI = (*Client)(nil)
+
+// New creates a fake Client with the default behavior.
+func New() *Client {
+	return &Client{links: make(map[string]*definitions.PaymentLinkDetails)}
+}
+
+// CreatePaymentLink implements sdk.BoldAPI. By default, it validates the
+// request and stores the payment link with the ACTIVE status.
+func (c *Client) CreatePaymentLink(ctx context.Context, req definitions.CreatePaymentLinkRequest, _ ...sdk.RequestOption) (*definitions.CreatePaymentLinkResponse, error) {
+	c.record(OperationCreatePaymentLink, req)
+	if c.CreatePaymentLinkFunc != nil {
+		return c.CreatePaymentLinkFunc(ctx, req)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, &sdk.RequestError{Endpoint: "/online/link/v1", Action: "create payment link", Err: err}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.links == nil {
+		c.links = make(map[string]*definitions.PaymentLinkDetails)
+	}
+	c.sequence++
+	details := fixtures.NewPaymentLink(c.sequence, req)
+	c.links[details.ID] = &details
+
+	return &definitions.CreatePaymentLinkResponse{
+		Payload: definitions.PaymentLinkData{PaymentLink: details.ID, URL: fixtures.PaymentLinkURL(details.ID)},
+		Errors:  []definitions.ErrorField{},
+	}, nil
+}
+
+// GetPaymentLinkData implements sdk.BoldAPI. By default, it returns the
+// stored payment link, or an error matching sdk.ErrNotFound.
+func (c *Client) GetPaymentLinkData(ctx context.Context, paymentLinkId string, _ ...sdk.RequestOption) (*definitions.GetPaymentLinkDataResponse, error) {
+	c.record(OperationGetPaymentLinkData, paymentLinkId)
+	if c.GetPaymentLinkDataFunc != nil {
+		return c.GetPaymentLinkDataFunc(ctx, paymentLinkId)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	details, ok := c.links[paymentLinkId]
+	if !ok {
+		return nil, notFound("/online/link/v1/"+paymentLinkId, "get data of payment link", "Payment link not found")
+	}
+	return &definitions.GetPaymentLinkDataResponse{PaymentLinkDetails: *details}, nil
+}
+
+// GetPaymentMethodsForPaymentLink implements sdk.BoldAPI. By default, it
+// returns the same payment methods as boldtest.DefaultPaymentMethods.
+func (c *Client) GetPaymentMethodsForPaymentLink(ctx context.Context, _ ...sdk.RequestOption) (*definitions.GetPaymentMethodsForPaymentLinkResponse, error) {
+	c.record(OperationGetPaymentMethodsForPaymentLink, nil)
+	if c.GetPaymentMethodsForPaymentLinkFunc != nil {
+		return c.GetPaymentMethodsForPaymentLinkFunc(ctx)
+	}
+
+	methods := make(definitions.PaymentMethodsMap, len(fixtures.PaymentMethods))
+	for method, limits := range fixtures.PaymentMethods {
+		methods[method] = limits
+	}
+	return &definitions.GetPaymentMethodsForPaymentLinkResponse{
+		Payload: definitions.PaymentMethodsData{PaymentMethods: methods},
+		Errors:  []definitions.ErrorField{},
+	}, nil
+}
+
+// GetPaymentMethodsForIntegrationsAPI implements sdk.BoldAPI. By default, it
+// returns the same payment methods as boldtest.DefaultIntegrationPaymentMethods.
+func (c *Client) GetPaymentMethodsForIntegrationsAPI(ctx context.Context, _ ...sdk.RequestOption) (*definitions.GetPaymentMethodsForIntegrationsAPIResponse, error) {
+	c.record(OperationGetPaymentMethodsForIntegrationsAPI, nil)
+	if c.GetPaymentMethodsForIntegrationsAPIFunc != nil {
+		return c.GetPaymentMethodsForIntegrationsAPIFunc(ctx)
+	}
+
+	methods := append([]definitions.IntegrationPaymentMethod(nil), fixtures.IntegrationPaymentMethods...)
+	return &definitions.GetPaymentMethodsForIntegrationsAPIResponse{
+		Payload: definitions.IntegrationPaymentMethodsData{PaymentMethods: &methods},
+	}, nil
+}
+
+// GetBindedTerminalsForIntegrationsAPI implements sdk.BoldAPI. By default, it
+// returns the Terminals of the client, or an error matching sdk.ErrNotFound.
+func (c *Client) GetBindedTerminalsForIntegrationsAPI(ctx context.Context, _ ...sdk.RequestOption) (*definitions.GetBindedTerminalsForIntegrationsAPIResponse, error) {
+	c.record(OperationGetBindedTerminalsForIntegrationsAPI, nil)
+	if c.GetBindedTerminalsForIntegrationsAPIFunc != nil {
+		return c.GetBindedTerminalsForIntegrationsAPIFunc(ctx)
+	}
+
+	if len(c.Terminals) == 0 {
+		return nil, notFound("/payments/binded-terminals", "get binded terminals for integrations API", "Available terminals not found")
+	}
+
+	terminals := append([]definitions.TerminalInfo(nil), c.Terminals...)
+	return &definitions.GetBindedTerminalsForIntegrationsAPIResponse{
+		Payload: definitions.GetBindedTerminalsPayload{AvailableTerminals: &terminals},
+	}, nil
+}
+
+// CreatePaymentForIntegrationsAPI implements sdk.BoldAPI. By default, it
+// validates the request and returns a new integration ID.
+func (c *Client) CreatePaymentForIntegrationsAPI(ctx context.Context, req definitions.CreatePaymentForIntegrationsAPIRequest, _ ...sdk.RequestOption) (*definitions.CreatePaymentForIntegrationsAPIResponse, error) {
+	c.record(OperationCreatePaymentForIntegrationsAPI, req)
+	if c.CreatePaymentForIntegrationsAPIFunc != nil {
+		return c.CreatePaymentForIntegrationsAPIFunc(ctx, req)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, &sdk.RequestError{Endpoint: "/payments/app-checkout", Action: "create payment for integrations API", Err: err}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.sequence++
+	return &definitions.CreatePaymentForIntegrationsAPIResponse{
+		Payload: definitions.IntegrationPaymentData{IntegrationID: fmt.Sprintf("INT_FAKE%06d", c.sequence)},
+	}, nil
+}
+
+// SetPaymentLinkStatus changes the status of a stored payment link,
+// simulating the actions of the payer.
+func (c *Client) SetPaymentLinkStatus(id string, status definitions.PaymentLinkStatus) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	details, ok := c.links[id]
+	if !ok {
+		return fmt.Errorf("payment link %s not found", id)
+	}
+	details.Status = status
+	return nil
+}
+
+// Calls returns the calls received by the client, in order.
+func (c *Client) Calls() []Call {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]Call(nil), c.calls...)
+}
+
+// CallsTo returns the calls received by the client for the given operation, in order.
+func (c *Client) CallsTo(operation string) []Call {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var calls []Call
+	for _, call := range c.calls {
+		if call.Operation == operation {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+// Reset removes the recorded calls and the stored payment links, and restarts
+// the numbering of the generated IDs.
+func (c *Client) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.calls = nil
+	c.links = make(map[string]*definitions.PaymentLinkDetails)
+	c.sequence = 0
+}
+
+// record registers a call.
+func (c *Client) record(operation string, request any) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.calls = append(c.calls, Call{Operation: operation, Request: request, Time: time.Now()})
+}
+
+// notFound builds the error returned by the real client for HTTP 404 responses.
+func notFound(endpoint string, action string, message string) *sdk.APIError {
+	return &sdk.APIError{
+		StatusCode: http.StatusNotFound,
+		Body:       fmt.Appendf(nil, `{"errors":[{"message":%q}]}`, message),
+		Errors:     []definitions.ErrorField{{"message": message}},
+		Endpoint:   endpoint,
+		Action:     action,
+		Headers:    http.Header{},
+		Attempts:   1,
+	}
+}
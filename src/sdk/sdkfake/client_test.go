@@ -0,0 +1,135 @@
+package sdkfake_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/PChaparro/bold-co-sdk/src/definitions"
+	"github.com/PChaparro/bold-co-sdk/src/internal/tests"
+	"github.com/PChaparro/bold-co-sdk/src/sdk"
+	"github.com/PChaparro/bold-co-sdk/src/sdk/sdkfake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("payment links are stored in memory", func(t *testing.T) {
+		client := sdkfake.New()
+
+		req := tests.GetPayloadToCreateValidPaymentLink()
+		created, err := client.CreatePaymentLink(ctx, *req)
+		require.NoError(t, err)
+
+		details, err := client.GetPaymentLinkData(ctx, created.Payload.PaymentLink)
+		require.NoError(t, err)
+		assert.Equal(t, definitions.PaymentLinkStatusActive, details.Status)
+		assert.Equal(t, req.Amount.TotalAmount, details.Total)
+
+		require.NoError(t, client.SetPaymentLinkStatus(created.Payload.PaymentLink, definitions.PaymentLinkStatusPaid))
+		details, err = client.GetPaymentLinkData(ctx, created.Payload.PaymentLink)
+		require.NoError(t, err)
+		assert.Equal(t, definitions.PaymentLinkStatusPaid, details.Status)
+
+		_, err = client.GetPaymentLinkData(ctx, "LNK_MISSING")
+		assert.ErrorIs(t, err, sdk.ErrNotFound)
+	})
+
+	t.Run("invalid requests are rejected", func(t *testing.T) {
+		client := sdkfake.New()
+
+		_, err := client.CreatePaymentLink(ctx, definitions.CreatePaymentLinkRequest{AmountType: definitions.AmountTypeClose})
+		var validationErr *definitions.ValidationError
+		assert.ErrorAs(t, err, &validationErr)
+
+		_, err = client.CreatePaymentForIntegrationsAPI(ctx, definitions.CreatePaymentForIntegrationsAPIRequest{})
+		assert.ErrorAs(t, err, &validationErr)
+	})
+
+	t.Run("operations can be overridden", func(t *testing.T) {
+		client := sdkfake.New()
+		client.GetPaymentMethodsForPaymentLinkFunc = func(ctx context.Context) (*definitions.GetPaymentMethodsForPaymentLinkResponse, error) {
+			return nil, errors.New("unavailable")
+		}
+
+		_, err := client.GetPaymentMethodsForPaymentLink(ctx)
+		assert.EqualError(t, err, "unavailable")
+
+		_, err = client.GetPaymentMethodsForIntegrationsAPI(ctx)
+		assert.NoError(t, err)
+	})
+
+	t.Run("terminals", func(t *testing.T) {
+		client := sdkfake.New()
+
+		_, err := client.GetBindedTerminalsForIntegrationsAPI(ctx)
+		require.ErrorIs(t, err, sdk.ErrNotFound)
+
+		client.Terminals = []definitions.TerminalInfo{{TerminalModel: "N86", TerminalSerial: "N860W000000", Status: definitions.TerminalStatusBinded}}
+		terminals, err := client.GetBindedTerminalsForIntegrationsAPI(ctx)
+		require.NoError(t, err)
+		assert.Len(t, *terminals.Payload.AvailableTerminals, 1)
+
+		payment, err := client.CreatePaymentForIntegrationsAPI(ctx, *tests.GetPayloadToCreateValidPaymentForIntegrationsAPI())
+		require.NoError(t, err)
+		assert.NotEmpty(t, payment.Payload.IntegrationID)
+	})
+
+	t.Run("calls are recorded", func(t *testing.T) {
+		client := sdkfake.New()
+
+		_, _ = client.GetPaymentLinkData(ctx, "LNK_1")
+		_, _ = client.GetPaymentMethodsForPaymentLink(ctx)
+		_, _ = client.GetPaymentLinkData(ctx, "LNK_2")
+
+		calls := client.Calls()
+		require.Len(t, calls, 3)
+		assert.Equal(t, sdkfake.OperationGetPaymentMethodsForPaymentLink, calls[1].Operation)
+
+		calls = client.CallsTo(sdkfake.OperationGetPaymentLinkData)
+		require.Len(t, calls, 2)
+		assert.Equal(t, "LNK_2", calls[1].Request)
+
+		client.Reset()
+		assert.Empty(t, client.Calls())
+	})
+
+	t.Run("reset restarts the IDs", func(t *testing.T) {
+		client := sdkfake.New()
+
+		first, err := client.CreatePaymentLink(ctx, *tests.GetPayloadToCreateValidPaymentLink())
+		require.NoError(t, err)
+		_, err = client.CreatePaymentLink(ctx, *tests.GetPayloadToCreateValidPaymentLink())
+		require.NoError(t, err)
+
+		client.Reset()
+		again, err := client.CreatePaymentLink(ctx, *tests.GetPayloadToCreateValidPaymentLink())
+		require.NoError(t, err)
+		assert.Equal(t, first.Payload.PaymentLink, again.Payload.PaymentLink)
+
+		_, err = client.GetPaymentLinkData(ctx, again.Payload.PaymentLink)
+		require.NoError(t, err)
+	})
+
+	t.Run("drives a link watcher", func(t *testing.T) {
+		client := sdkfake.New()
+		created, err := client.CreatePaymentLink(ctx, *tests.GetPayloadToCreateValidPaymentLink())
+		require.NoError(t, err)
+		id := created.Payload.PaymentLink
+		require.NoError(t, client.SetPaymentLinkStatus(id, definitions.PaymentLinkStatusRejected))
+
+		watcher := sdk.NewLinkWatcher(client, sdk.LinkWatcherConfig{MinInterval: time.Millisecond, RateLimit: 1000})
+		require.NoError(t, watcher.Watch(ctx, id))
+
+		runCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+		go func() { _ = watcher.Run(runCtx) }()
+
+		change := <-watcher.Events()
+		assert.Equal(t, id, change.PaymentLinkID)
+		assert.Equal(t, definitions.PaymentLinkStatusRejected, change.To)
+	})
+}
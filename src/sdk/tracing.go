@@ -0,0 +1,127 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/PChaparro/bold-co-sdk/src/definitions"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TracerName is the name of the tracer used to create the spans of the client.
+const TracerName = "github.com/PChaparro/bold-co-sdk/src/sdk"
+
+// Attributes of the spans created by the client.
+const (
+	AttributeEndpoint       = attribute.Key("bold.endpoint")
+	AttributePaymentLinkID  = attribute.Key("bold.payment_link.id")
+	AttributeIntegrationID  = attribute.Key("bold.integration.id")
+	AttributeAttempts       = attribute.Key("bold.attempts")
+	AttributeRequestMethod  = attribute.Key("http.request.method")
+	AttributeResponseStatus = attribute.Key("http.response.status_code")
+	AttributeErrorType      = attribute.Key("error.type")
+)
+
+// operationSpan is the client span of an operation of the BoldClient.
+// A nil operationSpan is valid and does nothing, so callers do not need to
+// check whether tracing is enabled.
+type operationSpan struct {
+	span trace.Span
+}
+
+// operationSpanKey is the context key of the span of the current operation.
+type operationSpanKey struct{}
+
+// startSpan starts the client span of an operation, named after its action,
+// as a child of the span in the context. It returns a nil span when the
+// client has no TracerProvider.
+func (c *BoldClient) startSpan(ctx context.Context, params RequestParams) (context.Context, *operationSpan) {
+	if c.tracer == nil {
+		return ctx, nil
+	}
+
+	attributes := []attribute.KeyValue{AttributeEndpoint.String(params.Endpoint)}
+	if params.PaymentLinkID != "" {
+		attributes = append(attributes, AttributePaymentLinkID.String(params.PaymentLinkID))
+	}
+
+	ctx, span := c.tracer.Start(ctx, params.Action,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attributes...),
+	)
+	operation := &operationSpan{span: span}
+
+	return context.WithValue(ctx, operationSpanKey{}, operation), operation
+}
+
+// spanFromContext returns the span of the current operation, if any.
+func spanFromContext(ctx context.Context) *operationSpan {
+	span, _ := ctx.Value(operationSpanKey{}).(*operationSpan)
+	return span
+}
+
+// setAttributes adds attributes to the span.
+func (s *operationSpan) setAttributes(attributes ...attribute.KeyValue) {
+	if s == nil {
+		return
+	}
+	s.span.SetAttributes(attributes...)
+}
+
+// setResponse records the request method, the status code and the attempts of a response.
+func (s *operationSpan) setResponse(method string, response *Response) {
+	if s == nil {
+		return
+	}
+
+	s.span.SetAttributes(
+		AttributeRequestMethod.String(method),
+		AttributeResponseStatus.Int(response.StatusCode),
+		AttributeAttempts.Int(response.Attempts),
+	)
+}
+
+// end records the result of the operation and ends the span.
+func (s *operationSpan) end(err error) {
+	if s == nil {
+		return
+	}
+
+	if err != nil {
+		var requestErr *RequestError
+		if errors.As(err, &requestErr) && requestErr.Attempts > 0 {
+			s.span.SetAttributes(AttributeAttempts.Int(requestErr.Attempts))
+		}
+
+		s.span.SetAttributes(AttributeErrorType.String(errorType(err)))
+		s.span.SetStatus(codes.Error, err.Error())
+	}
+
+	s.span.End()
+}
+
+// errorType returns a low-cardinality description of an error, used as the
+// error.type attribute of the spans.
+func errorType(err error) string {
+	var apiErr *APIError
+	var requestErr *RequestError
+	var validationErr *definitions.ValidationError
+
+	switch {
+	case errors.As(err, &validationErr):
+		return "invalid_request"
+	case errors.Is(err, context.DeadlineExceeded):
+		return "timeout"
+	case errors.Is(err, context.Canceled):
+		return "canceled"
+	case errors.As(err, &apiErr):
+		return strconv.Itoa(apiErr.StatusCode)
+	case errors.As(err, &requestErr):
+		return "request_error"
+	default:
+		return "_OTHER"
+	}
+}
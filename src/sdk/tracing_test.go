@@ -0,0 +1,133 @@
+package sdk
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/PChaparro/bold-co-sdk/src/boldtest"
+	"github.com/PChaparro/bold-co-sdk/src/definitions"
+	"github.com/PChaparro/bold-co-sdk/src/internal/tests"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// spanAttributes returns the attributes of a span as a map.
+func spanAttributes(span tracetest.SpanStub) map[attribute.Key]attribute.Value {
+	attributes := make(map[attribute.Key]attribute.Value)
+	for _, kv := range span.Attributes {
+		attributes[kv.Key] = kv.Value
+	}
+	return attributes
+}
+
+func TestTracing(t *testing.T) {
+	server := boldtest.NewServer(boldtest.Config{})
+	defer server.Close()
+
+	newClient := func(t *testing.T, config ClientConfig) (*BoldClient, *tracetest.InMemoryExporter, trace.Tracer) {
+		exporter := tracetest.NewInMemoryExporter()
+		provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+		t.Cleanup(func() { _ = provider.Shutdown(context.Background()) })
+
+		config.ApiKey = boldtest.DefaultAPIKey
+		config.BaseURL = server.URL
+		config.TracerProvider = provider
+		return NewClient(config), exporter, provider.Tracer("test")
+	}
+
+	t.Run("operations create client spans", func(t *testing.T) {
+		client, exporter, tracer := newClient(t, ClientConfig{})
+
+		ctx, parent := tracer.Start(context.Background(), "checkout")
+		created, err := client.CreatePaymentLink(ctx, *tests.GetPayloadToCreateValidPaymentLink())
+		require.NoError(t, err)
+		_, err = client.GetPaymentLinkData(ctx, created.Payload.PaymentLink)
+		require.NoError(t, err)
+		parent.End()
+
+		spans := exporter.GetSpans()
+		require.Len(t, spans, 3)
+
+		create := spans[0]
+		assert.Equal(t, "create payment link", create.Name)
+		assert.Equal(t, trace.SpanKindClient, create.SpanKind)
+		assert.Equal(t, parent.SpanContext().SpanID(), create.Parent.SpanID(), "the span is a child of the span in the context")
+
+		attributes := spanAttributes(create)
+		assert.Equal(t, "/online/link/v1", attributes[AttributeEndpoint].AsString())
+		assert.Equal(t, http.MethodPost, attributes[AttributeRequestMethod].AsString())
+		assert.Equal(t, int64(http.StatusOK), attributes[AttributeResponseStatus].AsInt64())
+		assert.Equal(t, int64(1), attributes[AttributeAttempts].AsInt64())
+		assert.Equal(t, created.Payload.PaymentLink, attributes[AttributePaymentLinkID].AsString())
+
+		get := spans[1]
+		assert.Equal(t, "get data of payment link", get.Name)
+		assert.Equal(t, created.Payload.PaymentLink, spanAttributes(get)[AttributePaymentLinkID].AsString())
+	})
+
+	t.Run("integration ID is recorded", func(t *testing.T) {
+		client, exporter, _ := newClient(t, ClientConfig{})
+		server.SetTerminals(boldtest.Terminal{Model: "N86", Serial: "N860W000000"})
+
+		payment, err := client.CreatePaymentForIntegrationsAPI(context.Background(), *tests.GetPayloadToCreateValidPaymentForIntegrationsAPI())
+		require.NoError(t, err)
+
+		spans := exporter.GetSpans()
+		require.Len(t, spans, 1)
+		assert.Equal(t, payment.Payload.IntegrationID, spanAttributes(spans[0])[AttributeIntegrationID].AsString())
+	})
+
+	t.Run("errors are recorded", func(t *testing.T) {
+		client, exporter, _ := newClient(t, ClientConfig{})
+
+		_, err := client.GetPaymentLinkData(context.Background(), "LNK_MISSING")
+		require.ErrorIs(t, err, ErrNotFound)
+
+		_, err = client.CreatePaymentLink(context.Background(), definitions.CreatePaymentLinkRequest{})
+		require.Error(t, err)
+
+		spans := exporter.GetSpans()
+		require.Len(t, spans, 2)
+
+		assert.Equal(t, codes.Error, spans[0].Status.Code)
+		assert.Equal(t, "404", spanAttributes(spans[0])[AttributeErrorType].AsString())
+		assert.Equal(t, int64(http.StatusNotFound), spanAttributes(spans[0])[AttributeResponseStatus].AsInt64())
+
+		assert.Equal(t, codes.Error, spans[1].Status.Code)
+		assert.Equal(t, "invalid_request", spanAttributes(spans[1])[AttributeErrorType].AsString())
+	})
+
+	t.Run("preflight requests are child spans", func(t *testing.T) {
+		client, exporter, _ := newClient(t, ClientConfig{Preflight: &PreflightConfig{}})
+
+		_, err := client.CreatePaymentLink(context.Background(), *tests.GetPayloadToCreateValidPaymentLink())
+		require.NoError(t, err)
+
+		spans := exporter.GetSpans()
+		require.Len(t, spans, 2)
+		assert.Equal(t, "get available payment methods for payment link", spans[0].Name)
+		assert.Equal(t, spans[1].SpanContext.SpanID(), spans[0].Parent.SpanID())
+	})
+
+	t.Run("no spans without a tracer provider", func(t *testing.T) {
+		exporter := tracetest.NewInMemoryExporter()
+		provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+		defer func() { _ = provider.Shutdown(context.Background()) }()
+
+		ctx, parent := provider.Tracer("test").Start(context.Background(), "checkout")
+		client := NewClient(ClientConfig{ApiKey: boldtest.DefaultAPIKey, BaseURL: server.URL})
+		_, err := client.GetPaymentMethodsForPaymentLink(ctx)
+		require.NoError(t, err)
+		parent.End()
+
+		spans := exporter.GetSpans()
+		require.Len(t, spans, 1)
+		assert.Empty(t, spans[0].Attributes, "the span of the caller is not modified")
+	})
+}
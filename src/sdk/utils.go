@@ -3,16 +3,39 @@ package sdk
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
+	"strings"
+	"time"
 
 	httpClient "github.com/PChaparro/bold-co-sdk/src/internal/http"
 )
 
 // RequestParams encapsulates the necessary parameters to make requests to the Bold API.
 type RequestParams struct {
-	Endpoint string // The endpoint path, not including the baseURL.
-	Action   string // Description of the action being performed (e.g., "create payment link").
-	Body     any    // The request body for POST requests (optional for GET).
+	Endpoint      string     // The endpoint path, not including the baseURL.
+	Action        string     // Description of the action being performed (e.g., "create payment link").
+	Body          any        // The request body for POST requests (optional for GET).
+	CacheEntry    CacheEntry // The cache entry of the response, if it can be cached (optional).
+	PaymentLinkID string     // The payment link the request refers to, recorded in the spans (optional).
+}
+
+// validatable is implemented by the requests that can be validated before being sent.
+type validatable interface {
+	Validate() error
+}
+
+// validate runs the client-side validation of the request, unless it is
+// disabled in the client configuration.
+func (c *BoldClient) validate(params RequestParams, req validatable) error {
+	if c.config.DisableValidation {
+		return nil
+	}
+	if err := req.Validate(); err != nil {
+		return &RequestError{Endpoint: params.Endpoint, Action: params.Action, Err: err}
+	}
+	return nil
 }
 
 // sendGETRequest is a generic function to send GET requests to the Bold API.
@@ -21,34 +44,9 @@ func sendGETRequest[T any](
 	c *BoldClient,
 	ctx context.Context,
 	params RequestParams,
+	opts ...RequestOption,
 ) (*T, error) {
-	// Build the complete URL.
-	url := fmt.Sprintf("%s%s", c.config.BaseURL, params.Endpoint)
-
-	// Perform the GET request.
-	response, err := c.httpClient.GET(ctx, httpClient.RequestOptions{
-		URL:     url,
-		Headers: httpClient.GetDefaultHeadersForBoldAPI(c.config.ApiKey),
-	})
-
-	// Handle request errors.
-	if err != nil {
-		return nil, fmt.Errorf("failed to %s: %w", params.Action, err)
-	}
-
-	// Verify non-successful status code.
-	if response.StatusCode < 200 || response.StatusCode >= 300 {
-		return nil, fmt.Errorf("bold API error - status code: %d, response: %s",
-			response.StatusCode, string(response.Body))
-	}
-
-	// Parse the response.
-	var result T
-	if err := json.Unmarshal(response.Body, &result); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w", err)
-	}
-
-	return &result, nil
+	return sendRequest[T](c, ctx, http.MethodGet, params, opts)
 }
 
 // sendPOSTRequest is a generic function to send POST requests to the Bold API.
@@ -57,26 +55,71 @@ func sendPOSTRequest[T any](
 	c *BoldClient,
 	ctx context.Context,
 	params RequestParams,
+	opts ...RequestOption,
+) (*T, error) {
+	return sendRequest[T](c, ctx, http.MethodPost, params, opts)
+}
+
+// sendRequest sends a request to the Bold API through the middleware chain
+// of the client and parses the response into T.
+func sendRequest[T any](
+	c *BoldClient,
+	ctx context.Context,
+	method string,
+	params RequestParams,
+	opts []RequestOption,
 ) (*T, error) {
-	// Build the complete URL.
-	url := fmt.Sprintf("%s%s", c.config.BaseURL, params.Endpoint)
+	options := newRequestOptions(opts)
+
+	// Build the request.
+	req := &Request{
+		Action:   params.Action,
+		Method:   method,
+		Endpoint: params.Endpoint,
+		URL:      fmt.Sprintf("%s%s", c.config.BaseURL, params.Endpoint),
+		Headers:  http.Header{},
+		Body:     params.Body,
+	}
+	for key, value := range httpClient.GetDefaultHeadersForBoldAPI(c.config.ApiKey) {
+		req.Headers.Set(key, value)
+	}
+
+	// Perform the request.
+	var response *Response
+	var err error
+	if c.cache != nil && params.CacheEntry != "" {
+		// The request may be shared with other calls, so the per-call timeout
+		// only limits how long this call waits for it
+		handler := chainMiddlewares(c.newTransportHandler(requestOptions{}), c.config.Middlewares)
+		waitCtx, cancel := withTimeout(ctx, options.timeout)
+		defer cancel()
 
-	// Perform the POST request.
-	response, err := c.httpClient.POST(ctx, httpClient.RequestOptions{
-		URL:     url,
-		Headers: httpClient.GetDefaultHeadersForBoldAPI(c.config.ApiKey),
-		Body:    params.Body,
-	})
+		var hit bool
+		response, hit, err = c.cache.do(waitCtx, params.CacheEntry, options.bypassCache, func(ctx context.Context) (*Response, error) {
+			return handler(ctx, req)
+		})
+		if c.config.Metrics != nil {
+			c.config.Metrics.ObserveCacheLookup(params.Action, hit)
+		}
+	} else {
+		handler := chainMiddlewares(c.newTransportHandler(options), c.config.Middlewares)
+		response, err = handler(ctx, req)
+	}
 
 	// Handle request errors.
+	if response != nil {
+		spanFromContext(ctx).setResponse(method, response)
+	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to %s: %w", params.Action, err)
+		return nil, newRequestError(params, err)
+	}
+	if response == nil {
+		return nil, newRequestError(params, errors.New("middleware returned a nil response"))
 	}
 
 	// Verify non-successful status code.
 	if response.StatusCode < 200 || response.StatusCode >= 300 {
-		return nil, fmt.Errorf("bold API error - status code: %d, response: %s",
-			response.StatusCode, string(response.Body))
+		return nil, newAPIError(params, response)
 	}
 
 	// Parse the response.
@@ -87,3 +130,85 @@ func sendPOSTRequest[T any](
 
 	return &result, nil
 }
+
+// newTransportHandler returns the innermost handler of the middleware chain,
+// which sends the request using the internal HTTP client.
+func (c *BoldClient) newTransportHandler(options requestOptions) Handler {
+	return func(ctx context.Context, req *Request) (*Response, error) {
+		headers := make(map[string]string, len(req.Headers))
+		for key, values := range req.Headers {
+			headers[key] = strings.Join(values, ", ")
+		}
+
+		requestOptions := httpClient.RequestOptions{
+			URL:         req.URL,
+			Headers:     headers,
+			Body:        req.Body,
+			Timeout:     options.timeout,
+			RetryPolicy: c.retryPolicy,
+		}
+
+		logger := c.newRequestLogger(ctx, req)
+		metrics := c.config.Metrics
+		if c.limiter != nil {
+			requestOptions.BeforeAttempt = func(ctx context.Context, attempt int) error {
+				return c.limiter.wait(ctx, req.Endpoint)
+			}
+		}
+		requestOptions.AfterAttempt = func(attempt httpClient.Attempt) {
+			if c.limiter != nil {
+				c.limiter.observe(req.Endpoint, attempt)
+			}
+			if logger != nil {
+				logger.afterAttempt(attempt)
+			}
+			if metrics != nil && attempt.Retry {
+				metrics.ObserveRetry(req.Action)
+			}
+		}
+		start := time.Now()
+
+		var response *httpClient.HTTPResponse
+		var err error
+		switch req.Method {
+		case http.MethodGet:
+			response, err = c.httpClient.GET(ctx, requestOptions)
+		case http.MethodPost:
+			response, err = c.httpClient.POST(ctx, requestOptions)
+		default:
+			err = fmt.Errorf("unsupported HTTP method: %s", req.Method)
+		}
+
+		var result *Response
+		if err == nil {
+			result = &Response{
+				StatusCode: response.StatusCode,
+				Body:       response.Body,
+				Headers:    response.Headers,
+				Attempts:   response.Attempts,
+			}
+		}
+
+		if logger != nil {
+			logger.done(result, err)
+		}
+		if metrics != nil {
+			statusCode := 0
+			if result != nil {
+				statusCode = result.StatusCode
+			}
+			metrics.ObserveRequest(req.Action, statusCode, time.Since(start))
+		}
+
+		return result, err
+	}
+}
+
+// withTimeout returns a copy of the context with the given timeout applied.
+// If the timeout is not positive, the context is returned unchanged.
+func withTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, timeout)
+}
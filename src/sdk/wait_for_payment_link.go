@@ -0,0 +1,199 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/PChaparro/bold-co-sdk/src/definitions"
+)
+
+// Default values of the WaitOptions.
+const (
+	DefaultWaitInitialInterval = 2 * time.Second
+	DefaultWaitMaxInterval     = 30 * time.Second
+	DefaultWaitMultiplier      = 1.5
+	DefaultWaitGracePeriod     = time.Minute
+	DefaultWaitMaxErrors       = 3
+)
+
+// ErrPaymentLinkExpired is returned by WaitForPaymentLinkFinalStatus when the
+// expiration date of the payment link (plus the grace period) passes without
+// Bold reporting a final status.
+var ErrPaymentLinkExpired = errors.New("bold: payment link expired without a final status")
+
+// PaymentLinkStatusChange describes a change of status observed while polling a payment link.
+type PaymentLinkStatusChange struct {
+	// PaymentLinkID is the identifier of the payment link.
+	PaymentLinkID string
+
+	// From is the previous status. It is empty for the first observed status.
+	From definitions.PaymentLinkStatus
+
+	// To is the new status.
+	To definitions.PaymentLinkStatus
+
+	// Details contains the data of the payment link when the change was observed.
+	Details definitions.PaymentLinkDetails
+
+	// ObservedAt is when the change was observed.
+	ObservedAt time.Time
+}
+
+// WaitOptions contains the options of WaitForPaymentLinkFinalStatus.
+// Every field is optional.
+type WaitOptions struct {
+	// InitialInterval is the delay between the first polls. It is also used
+	// again after every status change. Defaults to DefaultWaitInitialInterval.
+	InitialInterval time.Duration
+
+	// MaxInterval caps the delay between two polls, including InitialInterval.
+	// Defaults to DefaultWaitMaxInterval.
+	MaxInterval time.Duration
+
+	// Multiplier increases the delay after every poll without changes.
+	// Values lower than 1 default to DefaultWaitMultiplier.
+	Multiplier float64
+
+	// GracePeriod is how long the payment link is polled after its expiration
+	// date, waiting for Bold to report its final status. Defaults to DefaultWaitGracePeriod.
+	GracePeriod time.Duration
+
+	// MaxErrors is the number of consecutive transient errors (e.g., rate
+	// limits, server or connection errors) tolerated before giving up.
+	// Defaults to DefaultWaitMaxErrors.
+	MaxErrors int
+
+	// OnStatusChange is called, from the polling goroutine, with every observed status change.
+	OnStatusChange func(change PaymentLinkStatusChange)
+
+	// StatusChanges receives every observed status change. Sends block until
+	// the change is received or the context is done. It is not closed.
+	StatusChanges chan<- PaymentLinkStatusChange
+
+	// RequestOptions are applied to every GetPaymentLinkData call.
+	RequestOptions []RequestOption
+}
+
+// newWaitOptions returns a copy of the given options with the defaults applied.
+func newWaitOptions(opts *WaitOptions) WaitOptions {
+	var options WaitOptions
+	if opts != nil {
+		options = *opts
+	}
+
+	if options.InitialInterval <= 0 {
+		options.InitialInterval = DefaultWaitInitialInterval
+	}
+	if options.MaxInterval <= 0 {
+		options.MaxInterval = DefaultWaitMaxInterval
+	}
+	options.InitialInterval = min(options.InitialInterval, options.MaxInterval)
+	if options.Multiplier < 1 {
+		options.Multiplier = DefaultWaitMultiplier
+	}
+	if options.GracePeriod <= 0 {
+		options.GracePeriod = DefaultWaitGracePeriod
+	}
+	if options.MaxErrors <= 0 {
+		options.MaxErrors = DefaultWaitMaxErrors
+	}
+
+	return options
+}
+
+// WaitForPaymentLinkFinalStatus polls a payment link until it reaches a final
+// status (PAID, REJECTED, EXPIRED or CANCELED), returning its last data.
+// The delay between polls grows exponentially while the status does not change.
+//
+// It stops early with ErrPaymentLinkExpired when the expiration date of the
+// payment link passes without a final status, and with the context error when
+// the context is done; in both cases the last observed data is also returned.
+func (client *BoldClient) WaitForPaymentLinkFinalStatus(ctx context.Context, paymentLinkId string, opts *WaitOptions) (*definitions.PaymentLinkDetails, error) {
+	options := newWaitOptions(opts)
+
+	var last *definitions.PaymentLinkDetails
+	interval := options.InitialInterval
+	errorsInRow := 0
+
+	for {
+		response, err := client.GetPaymentLinkData(ctx, paymentLinkId, options.RequestOptions...)
+		if err != nil {
+			errorsInRow++
+			if ctx.Err() != nil {
+				return last, ctx.Err()
+			}
+			if !isTransientError(err) || errorsInRow > options.MaxErrors {
+				return last, err
+			}
+		} else {
+			errorsInRow = 0
+			details := response.PaymentLinkDetails
+
+			if last == nil || last.Status != details.Status {
+				change := PaymentLinkStatusChange{
+					PaymentLinkID: paymentLinkId,
+					To:            details.Status,
+					Details:       details,
+					ObservedAt:    time.Now(),
+				}
+				if last != nil {
+					change.From = last.Status
+				}
+				if err := options.notify(ctx, change); err != nil {
+					return &details, err
+				}
+				interval = options.InitialInterval
+			}
+			last = &details
+
+			if details.Status.IsTerminal() {
+				return last, nil
+			}
+		}
+
+		// Do not wait beyond the expiration date of the payment link
+		delay := interval
+		if last != nil && last.ExpirationDate != nil {
+			deadline := time.Unix(0, *last.ExpirationDate).Add(options.GracePeriod)
+			remaining := time.Until(deadline)
+			if remaining <= 0 {
+				return last, ErrPaymentLinkExpired
+			}
+			delay = min(delay, remaining)
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return last, ctx.Err()
+		case <-timer.C:
+		}
+
+		interval = min(time.Duration(float64(interval)*options.Multiplier), options.MaxInterval)
+	}
+}
+
+// notify reports a status change through the callback and the channel of the options.
+func (o WaitOptions) notify(ctx context.Context, change PaymentLinkStatusChange) error {
+	if o.OnStatusChange != nil {
+		o.OnStatusChange(change)
+	}
+
+	if o.StatusChanges != nil {
+		select {
+		case o.StatusChanges <- change:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	return nil
+}
+
+// isTransientError reports whether a failed call may succeed if it is sent again.
+func isTransientError(err error) bool {
+	var requestErr *RequestError
+	return errors.Is(err, ErrRateLimited) || errors.Is(err, ErrServer) || errors.As(err, &requestErr)
+}
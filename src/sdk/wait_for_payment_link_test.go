@@ -0,0 +1,121 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/PChaparro/bold-co-sdk/src/definitions"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newPaymentLinkServer returns a server that reports the given statuses for
+// the payment link, one per poll, repeating the last one.
+func newPaymentLinkServer(t *testing.T, expiration time.Time, statuses ...string) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		call := int(calls.Add(1))
+		status := statuses[min(call, len(statuses))-1]
+		if status == "500" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		_, _ = fmt.Fprintf(w, `{"id":"LNK_TEST","status":%q,"expiration_date":%d,"total":10000}`, status, expiration.UnixNano())
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &calls
+}
+
+func TestWaitForPaymentLinkFinalStatus(t *testing.T) {
+	options := func() *WaitOptions {
+		return &WaitOptions{InitialInterval: time.Millisecond, MaxInterval: 5 * time.Millisecond}
+	}
+
+	t.Run("returns the final status and reports the changes", func(t *testing.T) {
+		server, calls := newPaymentLinkServer(t, time.Now().Add(time.Hour), "ACTIVE", "ACTIVE", "500", "PROCESSING", "PAID")
+		client := NewClient(ClientConfig{ApiKey: "test-api-key", BaseURL: server.URL})
+
+		changes := make(chan PaymentLinkStatusChange, 10)
+		var callbacks []PaymentLinkStatusChange
+		opts := options()
+		opts.StatusChanges = changes
+		opts.OnStatusChange = func(change PaymentLinkStatusChange) { callbacks = append(callbacks, change) }
+
+		details, err := client.WaitForPaymentLinkFinalStatus(context.Background(), "LNK_TEST", opts)
+
+		require.NoError(t, err)
+		assert.Equal(t, definitions.PaymentLinkStatusPaid, details.Status)
+		assert.Equal(t, int32(5), calls.Load())
+
+		close(changes)
+		var transitions []string
+		for change := range changes {
+			assert.Equal(t, "LNK_TEST", change.PaymentLinkID)
+			transitions = append(transitions, fmt.Sprintf("%s->%s", change.From, change.To))
+		}
+		assert.Equal(t, []string{"->ACTIVE", "ACTIVE->PROCESSING", "PROCESSING->PAID"}, transitions)
+		assert.Len(t, callbacks, 3)
+	})
+
+	t.Run("the initial interval is capped", func(t *testing.T) {
+		server, calls := newPaymentLinkServer(t, time.Now().Add(time.Hour), "ACTIVE", "PAID")
+		client := NewClient(ClientConfig{ApiKey: "test-api-key", BaseURL: server.URL})
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
+		details, err := client.WaitForPaymentLinkFinalStatus(ctx, "LNK_TEST", &WaitOptions{InitialInterval: time.Hour, MaxInterval: 5 * time.Millisecond})
+
+		require.NoError(t, err)
+		assert.Equal(t, definitions.PaymentLinkStatusPaid, details.Status)
+		assert.Equal(t, int32(2), calls.Load())
+	})
+
+	t.Run("stops after the expiration date", func(t *testing.T) {
+		server, _ := newPaymentLinkServer(t, time.Now().Add(20*time.Millisecond), "ACTIVE")
+		client := NewClient(ClientConfig{ApiKey: "test-api-key", BaseURL: server.URL})
+
+		opts := options()
+		opts.GracePeriod = 10 * time.Millisecond
+
+		details, err := client.WaitForPaymentLinkFinalStatus(context.Background(), "LNK_TEST", opts)
+
+		require.ErrorIs(t, err, ErrPaymentLinkExpired)
+		assert.Equal(t, definitions.PaymentLinkStatusActive, details.Status)
+	})
+
+	t.Run("gives up after too many errors", func(t *testing.T) {
+		server, calls := newPaymentLinkServer(t, time.Now().Add(time.Hour), "500")
+		client := NewClient(ClientConfig{ApiKey: "test-api-key", BaseURL: server.URL})
+
+		opts := options()
+		opts.MaxErrors = 2
+
+		details, err := client.WaitForPaymentLinkFinalStatus(context.Background(), "LNK_TEST", opts)
+
+		require.ErrorIs(t, err, ErrServer)
+		assert.Nil(t, details)
+		assert.Equal(t, int32(3), calls.Load())
+	})
+
+	t.Run("respects the context", func(t *testing.T) {
+		server, _ := newPaymentLinkServer(t, time.Now().Add(time.Hour), "ACTIVE")
+		client := NewClient(ClientConfig{ApiKey: "test-api-key", BaseURL: server.URL})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+		defer cancel()
+
+		details, err := client.WaitForPaymentLinkFinalStatus(ctx, "LNK_TEST", options())
+
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Equal(t, definitions.PaymentLinkStatusActive, details.Status)
+	})
+}
@@ -0,0 +1,234 @@
+// Package tax computes the Colombian tax breakdowns (IVA and impuesto al consumo)
+// required to build the amounts of the Bold API requests, making sure the bases,
+// tax values and tip always sum exactly to the total amount.
+package tax
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/PChaparro/bold-co-sdk/src/definitions"
+)
+
+// Rate represents a tax rate, expressed in basis points (1% = 100).
+type Rate struct {
+	// Type is the type of the tax.
+	Type definitions.TaxType
+
+	// BasisPoints is the rate of the tax in basis points (e.g., 1900 for 19%).
+	BasisPoints int64
+}
+
+// Tax rates commonly used in Colombia.
+var (
+	IVA19        = Rate{Type: definitions.TaxTypeIVA, BasisPoints: 1900}        // General IVA rate.
+	IVA5         = Rate{Type: definitions.TaxTypeIVA, BasisPoints: 500}         // Reduced IVA rate.
+	IVA0         = Rate{Type: definitions.TaxTypeIVA, BasisPoints: 0}           // Exempt goods.
+	ImpoConsumo8 = Rate{Type: definitions.TaxTypeConsumption, BasisPoints: 800} // Impuesto al consumo (e.g., restaurants).
+)
+
+// fullRate is 100% expressed in basis points.
+const fullRate = 10000
+
+// RoundingMode defines how the tax bases and values are rounded.
+type RoundingMode int
+
+const (
+	RoundHalfUp   RoundingMode = iota // Round to the nearest unit, ties away from zero (default).
+	RoundHalfEven                     // Round to the nearest unit, ties to the nearest even unit.
+	RoundDown                         // Round toward zero.
+	RoundUp                           // Round away from zero.
+)
+
+// Line represents an item (or group of items) of a cart taxed at a single rate.
+type Line struct {
+	// Amount is the amount of the line. It includes the taxes when using
+	// Calculator.FromTotal, and excludes them when using Calculator.FromSubtotal.
+	Amount definitions.Money
+
+	// Rate is the tax rate applied to the line.
+	Rate Rate
+}
+
+// Calculator computes tax breakdowns.
+type Calculator struct {
+	// Currency of the amounts. If not provided, it defaults to COP.
+	Currency definitions.CurrencyType
+
+	// Rounding defines how the bases and values are rounded. Defaults to RoundHalfUp.
+	Rounding RoundingMode
+
+	// Unit is the rounding unit, in minor units. If not provided, it defaults
+	// to whole pesos (100) for COP and cents (1) for other currencies.
+	Unit int64
+}
+
+// Breakdown is the result of a tax calculation.
+type Breakdown struct {
+	// Currency of the amounts.
+	Currency definitions.CurrencyType
+
+	// Taxes contains one entry per tax rate, in the order they first appear in the lines.
+	Taxes []definitions.Tax
+
+	// Subtotal is the sum of the bases of the taxes.
+	Subtotal definitions.Money
+
+	// TaxTotal is the sum of the values of the taxes.
+	TaxTotal definitions.Money
+
+	// Tip is the tip amount.
+	Tip definitions.Money
+
+	// Total is the total amount: subtotal, taxes and tip.
+	Total definitions.Money
+}
+
+// group accumulates the amounts of the lines taxed at the same rate.
+type group struct {
+	rate  Rate
+	base  int64
+	value int64
+}
+
+// FromTotal computes the breakdown for lines whose amounts already include
+// their taxes, so the total amount is preserved exactly.
+func (c Calculator) FromTotal(lines []Line, tip definitions.Money) (*Breakdown, error) {
+	return c.calculate(lines, tip, func(g *group, amount int64, unit int64) {
+		// The gross amount is split into base and value, so they sum exactly to it
+		base := min(c.roundDiv(amount*fullRate, (fullRate+g.rate.BasisPoints)*unit)*unit, amount)
+		g.base += base
+		g.value += amount - base
+	})
+}
+
+// FromSubtotal computes the breakdown for lines whose amounts exclude their taxes.
+func (c Calculator) FromSubtotal(lines []Line, tip definitions.Money) (*Breakdown, error) {
+	return c.calculate(lines, tip, func(g *group, amount int64, unit int64) {
+		g.base += amount
+		g.value += c.roundDiv(amount*g.rate.BasisPoints, fullRate*unit) * unit
+	})
+}
+
+// calculate groups the lines by rate, computing the base and value of each
+// group with the given function.
+func (c Calculator) calculate(lines []Line, tip definitions.Money, compute func(g *group, amount int64, unit int64)) (*Breakdown, error) {
+	currency := c.Currency
+	if currency == "" {
+		currency = definitions.CurrencyTypeCOP
+	}
+
+	unit := c.Unit
+	if unit <= 0 {
+		unit = 1
+		if currency == definitions.CurrencyTypeCOP {
+			unit = 100
+		}
+	}
+
+	if len(lines) == 0 {
+		return nil, errors.New("at least one line is required")
+	}
+	if err := checkAmount(tip, currency); err != nil {
+		return nil, fmt.Errorf("invalid tip: %w", err)
+	}
+
+	// Sum the lines by rate, so each rate is rounded once
+	var groups []*group
+	amounts := make(map[Rate]int64)
+	for i, line := range lines {
+		if err := checkAmount(line.Amount, currency); err != nil {
+			return nil, fmt.Errorf("invalid amount in line %d: %w", i, err)
+		}
+		if line.Rate.BasisPoints < 0 || line.Rate.Type == "" {
+			return nil, fmt.Errorf("invalid tax rate in line %d", i)
+		}
+
+		if _, ok := amounts[line.Rate]; !ok {
+			groups = append(groups, &group{rate: line.Rate})
+		}
+		amounts[line.Rate] += line.Amount.MinorUnits()
+	}
+
+	breakdown := &Breakdown{
+		Currency: currency,
+		Taxes:    make([]definitions.Tax, 0, len(groups)),
+		Tip:      tip.WithCurrency(currency),
+	}
+
+	var subtotal, taxTotal int64
+	for _, g := range groups {
+		compute(g, amounts[g.rate], unit)
+		subtotal += g.base
+		taxTotal += g.value
+
+		breakdown.Taxes = append(breakdown.Taxes, definitions.Tax{
+			Type:  g.rate.Type,
+			Base:  definitions.NewMoney(g.base, currency).Float64(),
+			Value: definitions.NewMoney(g.value, currency).Float64(),
+		})
+	}
+
+	breakdown.Subtotal = definitions.NewMoney(subtotal, currency)
+	breakdown.TaxTotal = definitions.NewMoney(taxTotal, currency)
+	breakdown.Total = definitions.NewMoney(subtotal+taxTotal+tip.MinorUnits(), currency)
+
+	return breakdown, nil
+}
+
+// Amount returns the breakdown as the amount of a CreatePaymentLinkRequest.
+func (b *Breakdown) Amount() *definitions.Amount {
+	return &definitions.Amount{
+		Currency:    b.Currency,
+		Taxes:       b.Taxes,
+		TipAmount:   b.Tip.Float64(),
+		TotalAmount: b.Total.Float64(),
+	}
+}
+
+// IntegrationAmount returns the breakdown as the amount of a CreatePaymentForIntegrationsAPIRequest.
+func (b *Breakdown) IntegrationAmount() definitions.IntegrationAmount {
+	return definitions.IntegrationAmount{
+		Currency:    b.Currency,
+		Taxes:       b.Taxes,
+		TipAmount:   b.Tip.Float64(),
+		TotalAmount: b.Total.Float64(),
+	}
+}
+
+// checkAmount verifies that the amount is not negative and matches the currency.
+func checkAmount(amount definitions.Money, currency definitions.CurrencyType) error {
+	if amount.IsNegative() {
+		return fmt.Errorf("negative amount: %s", amount)
+	}
+	if amount.Currency() != "" && amount.Currency() != currency {
+		return fmt.Errorf("%w: %s and %s", definitions.ErrCurrencyMismatch, amount.Currency(), currency)
+	}
+	return nil
+}
+
+// roundDiv divides two non-negative numbers, rounding the result with the
+// rounding mode of the calculator.
+func (c Calculator) roundDiv(numerator, denominator int64) int64 {
+	quotient, remainder := numerator/denominator, numerator%denominator
+	if remainder == 0 {
+		return quotient
+	}
+
+	switch c.Rounding {
+	case RoundDown:
+		return quotient
+	case RoundUp:
+		return quotient + 1
+	case RoundHalfEven:
+		if doubled := 2 * remainder; doubled > denominator || (doubled == denominator && quotient%2 == 1) {
+			return quotient + 1
+		}
+		return quotient
+	default:
+		if 2*remainder >= denominator {
+			return quotient + 1
+		}
+		return quotient
+	}
+}
@@ -0,0 +1,131 @@
+package tax
+
+import (
+	"testing"
+
+	"github.com/PChaparro/bold-co-sdk/src/definitions"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// cop returns a COP amount from whole pesos.
+func cop(pesos int64) definitions.Money {
+	return definitions.NewMoney(pesos*100, definitions.CurrencyTypeCOP)
+}
+
+// assertConsistent verifies that the bases, values and tip sum exactly to the total.
+func assertConsistent(t *testing.T, breakdown *Breakdown) {
+	t.Helper()
+
+	var sum int64
+	for _, tax := range breakdown.Taxes {
+		sum += tax.BaseMoney(breakdown.Currency).MinorUnits() + tax.ValueMoney(breakdown.Currency).MinorUnits()
+	}
+	sum += breakdown.Tip.MinorUnits()
+
+	assert.Equal(t, breakdown.Total.MinorUnits(), sum)
+	assert.Equal(t, breakdown.Total.MinorUnits(), breakdown.Amount().TotalMoney().MinorUnits())
+}
+
+func TestCalculator(t *testing.T) {
+	calculator := Calculator{}
+
+	t.Run("from total with IVA 19%", func(t *testing.T) {
+		breakdown, err := calculator.FromTotal([]Line{{Amount: cop(10000), Rate: IVA19}}, definitions.Money{})
+		require.NoError(t, err)
+
+		assert.Equal(t, []definitions.Tax{{Type: definitions.TaxTypeIVA, Base: 8403, Value: 1597}}, breakdown.Taxes)
+		assert.Equal(t, float64(10000), breakdown.Amount().TotalAmount)
+		assert.Equal(t, definitions.CurrencyTypeCOP, breakdown.Amount().Currency)
+		assertConsistent(t, breakdown)
+	})
+
+	t.Run("from subtotal with tip", func(t *testing.T) {
+		breakdown, err := calculator.FromSubtotal([]Line{{Amount: cop(8403), Rate: IVA19}}, cop(1000))
+		require.NoError(t, err)
+
+		assert.Equal(t, []definitions.Tax{{Type: definitions.TaxTypeIVA, Base: 8403, Value: 1597}}, breakdown.Taxes)
+		assert.Equal(t, float64(1000), breakdown.IntegrationAmount().TipAmount)
+		assert.Equal(t, float64(11000), breakdown.IntegrationAmount().TotalAmount)
+		assertConsistent(t, breakdown)
+	})
+
+	t.Run("multi-rate cart", func(t *testing.T) {
+		breakdown, err := calculator.FromTotal([]Line{
+			{Amount: cop(5999), Rate: IVA19},
+			{Amount: cop(2100), Rate: IVA5},
+			{Amount: cop(3333), Rate: IVA19},
+			{Amount: cop(1500), Rate: IVA0},
+			{Amount: cop(25000), Rate: ImpoConsumo8},
+		}, cop(2500))
+		require.NoError(t, err)
+
+		assert.Equal(t, []definitions.Tax{
+			{Type: definitions.TaxTypeIVA, Base: 7842, Value: 1490},
+			{Type: definitions.TaxTypeIVA, Base: 2000, Value: 100},
+			{Type: definitions.TaxTypeIVA, Base: 1500, Value: 0},
+			{Type: definitions.TaxTypeConsumption, Base: 23148, Value: 1852},
+		}, breakdown.Taxes)
+		assert.Equal(t, cop(40432).MinorUnits(), breakdown.Total.MinorUnits())
+		assert.Equal(t, cop(34490).MinorUnits(), breakdown.Subtotal.MinorUnits())
+		assert.Equal(t, cop(3442).MinorUnits(), breakdown.TaxTotal.MinorUnits())
+		assertConsistent(t, breakdown)
+	})
+
+	t.Run("rounding modes", func(t *testing.T) {
+		// 10050 / 1.05 = 9571.43 and 150 * 0.05 = 7.5
+		cases := []struct {
+			mode          RoundingMode
+			baseFromTotal float64
+			valueFromBase float64
+		}{
+			{RoundHalfUp, 9571, 8},
+			{RoundHalfEven, 9571, 8},
+			{RoundDown, 9571, 7},
+			{RoundUp, 9572, 8},
+		}
+
+		for _, tc := range cases {
+			calculator := Calculator{Rounding: tc.mode}
+
+			breakdown, err := calculator.FromTotal([]Line{{Amount: cop(10050), Rate: IVA5}}, definitions.Money{})
+			require.NoError(t, err)
+			assert.Equal(t, tc.baseFromTotal, breakdown.Taxes[0].Base, "mode %d", tc.mode)
+			assertConsistent(t, breakdown)
+
+			breakdown, err = calculator.FromSubtotal([]Line{{Amount: cop(150), Rate: IVA5}}, definitions.Money{})
+			require.NoError(t, err)
+			assert.Equal(t, tc.valueFromBase, breakdown.Taxes[0].Value, "mode %d", tc.mode)
+			assertConsistent(t, breakdown)
+		}
+
+		// 130 * 0.05 = 6.5 rounds to the nearest even unit
+		breakdown, err := Calculator{Rounding: RoundHalfEven}.FromSubtotal([]Line{{Amount: cop(130), Rate: IVA5}}, definitions.Money{})
+		require.NoError(t, err)
+		assert.Equal(t, float64(6), breakdown.Taxes[0].Value)
+	})
+
+	t.Run("cents for USD", func(t *testing.T) {
+		breakdown, err := Calculator{Currency: definitions.CurrencyTypeUSD}.FromTotal([]Line{
+			{Amount: definitions.NewMoney(1999, definitions.CurrencyTypeUSD), Rate: IVA19},
+		}, definitions.Money{})
+		require.NoError(t, err)
+
+		assert.Equal(t, []definitions.Tax{{Type: definitions.TaxTypeIVA, Base: 16.8, Value: 3.19}}, breakdown.Taxes)
+		assertConsistent(t, breakdown)
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		_, err := calculator.FromTotal(nil, definitions.Money{})
+		assert.Error(t, err)
+
+		_, err = calculator.FromTotal([]Line{{Amount: cop(-1), Rate: IVA19}}, definitions.Money{})
+		assert.Error(t, err)
+
+		_, err = calculator.FromTotal([]Line{{Amount: cop(1), Rate: Rate{}}}, definitions.Money{})
+		assert.Error(t, err)
+
+		_, err = calculator.FromTotal([]Line{{Amount: definitions.NewMoney(100, definitions.CurrencyTypeUSD), Rate: IVA19}}, definitions.Money{})
+		assert.ErrorIs(t, err, definitions.ErrCurrencyMismatch)
+	})
+}
@@ -0,0 +1,122 @@
+package webhook
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// DefaultDedupTTL is how long the MemoryDedupStore remembers each notification
+// when the given TTL is not positive.
+const DefaultDedupTTL = 24 * time.Hour
+
+// DedupStatus represents the processing status of a notification in a DedupStore.
+type DedupStatus int
+
+const (
+	// DedupStatusNew means the notification was not seen before and is now
+	// reserved for processing.
+	DedupStatusNew DedupStatus = iota
+
+	// DedupStatusInProgress means the notification is being processed by
+	// another delivery.
+	DedupStatusInProgress
+
+	// DedupStatusProcessed means the notification was already processed.
+	DedupStatusProcessed
+)
+
+// DedupStore keeps track of the processed notifications, keyed by their ID,
+// so each notification is processed at most once even when Bold delivers it
+// several times. Implementations must be safe for concurrent use.
+type DedupStore interface {
+	// Reserve marks the notification as in progress if it was not seen before,
+	// and returns its previous status.
+	Reserve(ctx context.Context, id string) (DedupStatus, error)
+
+	// Complete marks a reserved notification as processed.
+	Complete(ctx context.Context, id string) error
+
+	// Release removes the reservation of a notification that failed to be
+	// processed, so a later delivery can process it again.
+	Release(ctx context.Context, id string) error
+}
+
+// dedupEntry is an entry of the MemoryDedupStore.
+type dedupEntry struct {
+	status    DedupStatus
+	expiresAt time.Time
+}
+
+// MemoryDedupStore is an in-memory DedupStore whose entries expire after a TTL.
+// It is only suitable for receivers running in a single process.
+type MemoryDedupStore struct {
+	mu        sync.Mutex
+	ttl       time.Duration
+	entries   map[string]dedupEntry
+	lastSweep time.Time
+}
+
+// NewMemoryDedupStore creates a new in-memory DedupStore that remembers each
+// notification for the given TTL. The TTL should be longer than the period in
+// which Bold retries the deliveries. If it is not positive, it defaults to
+// DefaultDedupTTL.
+func NewMemoryDedupStore(ttl time.Duration) *MemoryDedupStore {
+	if ttl <= 0 {
+		ttl = DefaultDedupTTL
+	}
+
+	return &MemoryDedupStore{
+		ttl:     ttl,
+		entries: make(map[string]dedupEntry),
+	}
+}
+
+// Reserve implements the DedupStore interface.
+func (s *MemoryDedupStore) Reserve(ctx context.Context, id string) (DedupStatus, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	s.sweep(now)
+
+	if entry, ok := s.entries[id]; ok && now.Before(entry.expiresAt) {
+		return entry.status, nil
+	}
+
+	s.entries[id] = dedupEntry{status: DedupStatusInProgress, expiresAt: now.Add(s.ttl)}
+	return DedupStatusNew, nil
+}
+
+// Complete implements the DedupStore interface.
+func (s *MemoryDedupStore) Complete(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.entries[id] = dedupEntry{status: DedupStatusProcessed, expiresAt: time.Now().Add(s.ttl)}
+	return nil
+}
+
+// Release implements the DedupStore interface.
+func (s *MemoryDedupStore) Release(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.entries, id)
+	return nil
+}
+
+// sweep removes the expired entries, at most once per TTL.
+// It must be called with the lock held.
+func (s *MemoryDedupStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < s.ttl {
+		return
+	}
+
+	for id, entry := range s.entries {
+		if !now.Before(entry.expiresAt) {
+			delete(s.entries, id)
+		}
+	}
+	s.lastSweep = now
+}
@@ -0,0 +1,184 @@
+// Package webhook provides the tools to receive the notifications that Bold
+// sends through webhooks, verifying their signature before processing them.
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/PChaparro/bold-co-sdk/src/definitions"
+)
+
+// DefaultMaxBodyBytes is the maximum size of a notification body when
+// HandlerConfig.MaxBodyBytes is not provided.
+const DefaultMaxBodyBytes = 1 << 20 // 1 MiB
+
+var (
+	// ErrBodyTooLarge is returned when the notification body exceeds the maximum allowed size.
+	ErrBodyTooLarge = errors.New("webhook: body too large")
+
+	// ErrInvalidPayload is returned when the notification body cannot be decoded.
+	ErrInvalidPayload = errors.New("webhook: invalid payload")
+)
+
+// EventHandlerFunc processes a notification whose signature was already verified.
+// Returning an error makes the handler respond with a non-successful status
+// code, so Bold delivers the notification again.
+type EventHandlerFunc func(ctx context.Context, notification *definitions.WebhookNotification) error
+
+// HandlerConfig contains the configuration options for the Handler.
+type HandlerConfig struct {
+	// SecretKey is the secret key of the merchant, used to verify the signature
+	// of the notifications. Bold uses an empty secret key in the sandbox environment.
+	SecretKey string
+
+	// OnEvent is called for every notification with a valid signature.
+	OnEvent EventHandlerFunc
+
+	// OnError is called when a notification cannot be processed (e.g., due to an
+	// invalid signature or an error returned by OnEvent). It is optional.
+	OnError func(r *http.Request, err error)
+
+	// MaxBodyBytes is the maximum size of the notification body.
+	// If not provided, it defaults to DefaultMaxBodyBytes.
+	MaxBodyBytes int64
+
+	// Metrics receives the notifications that fail the verification.
+	// It is optional.
+	Metrics Metrics
+}
+
+// Metrics receives the measurements of a Handler, so they can be exported to
+// a monitoring system. Implementations must be safe for concurrent use.
+type Metrics interface {
+	// ObserveVerificationFailure is called for every notification rejected
+	// before being processed, with the reason: "missing_signature",
+	// "invalid_signature", "body_too_large" or "invalid_payload".
+	ObserveVerificationFailure(reason string)
+}
+
+// Handler is an http.Handler that receives Bold notifications, verifies their
+// signature and dispatches them to the configured callback.
+type Handler struct {
+	config HandlerConfig
+}
+
+// NewHandler creates a new instance of the Handler.
+func NewHandler(config HandlerConfig) *Handler {
+	if config.MaxBodyBytes <= 0 {
+		config.MaxBodyBytes = DefaultMaxBodyBytes
+	}
+
+	return &Handler{
+		config: config,
+	}
+}
+
+// ServeHTTP implements the http.Handler interface. It responds with:
+//   - 200 when the notification was processed successfully.
+//   - 400 when the notification body cannot be decoded.
+//   - 401 when the signature is missing or invalid.
+//   - 405 when the request method is not POST.
+//   - 413 when the notification body is too large.
+//   - 500 when the callback returns an error.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		h.fail(w, r, http.StatusMethodNotAllowed, fmt.Errorf("webhook: method %s not allowed", r.Method))
+		return
+	}
+
+	notification, err := ParseRequest(r, h.config.SecretKey, h.config.MaxBodyBytes)
+	if err != nil {
+		if h.config.Metrics != nil {
+			h.config.Metrics.ObserveVerificationFailure(verificationFailureReason(err))
+		}
+		h.fail(w, r, statusCodeForError(err), err)
+		return
+	}
+
+	if h.config.OnEvent != nil {
+		if err := h.config.OnEvent(r.Context(), notification); err != nil {
+			h.fail(w, r, http.StatusInternalServerError, fmt.Errorf("webhook: failed to process notification %s: %w", notification.ID, err))
+			return
+		}
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// fail reports the error and writes the given status code.
+func (h *Handler) fail(w http.ResponseWriter, r *http.Request, statusCode int, err error) {
+	if h.config.OnError != nil {
+		h.config.OnError(r, err)
+	}
+	http.Error(w, http.StatusText(statusCode), statusCode)
+}
+
+// ParseRequest reads the body of a notification request, verifies its
+// signature and decodes it. The body is limited to maxBodyBytes bytes
+// (DefaultMaxBodyBytes if not positive).
+func ParseRequest(r *http.Request, secretKey string, maxBodyBytes int64) (*definitions.WebhookNotification, error) {
+	if maxBodyBytes <= 0 {
+		maxBodyBytes = DefaultMaxBodyBytes
+	}
+
+	// Read one more byte than allowed to detect oversized bodies
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxBodyBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("webhook: error reading body: %w", err)
+	}
+	if int64(len(body)) > maxBodyBytes {
+		return nil, ErrBodyTooLarge
+	}
+
+	return Parse(body, r.Header.Get(SignatureHeader), secretKey)
+}
+
+// Parse verifies the signature of a notification body and decodes it.
+func Parse(body []byte, signature string, secretKey string) (*definitions.WebhookNotification, error) {
+	if err := VerifySignature(body, signature, secretKey); err != nil {
+		return nil, err
+	}
+
+	var notification definitions.WebhookNotification
+	if err := json.Unmarshal(body, &notification); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidPayload, err)
+	}
+
+	return &notification, nil
+}
+
+// statusCodeForError returns the HTTP status code to respond with for the
+// errors returned by ParseRequest.
+func statusCodeForError(err error) int {
+	switch {
+	case errors.Is(err, ErrMissingSignature), errors.Is(err, ErrInvalidSignature):
+		return http.StatusUnauthorized
+	case errors.Is(err, ErrBodyTooLarge):
+		return http.StatusRequestEntityTooLarge
+	default:
+		// Invalid payloads and errors reading the body
+		return http.StatusBadRequest
+	}
+}
+
+// verificationFailureReason returns the reason reported to the Metrics for
+// the errors returned by ParseRequest.
+func verificationFailureReason(err error) string {
+	switch {
+	case errors.Is(err, ErrMissingSignature):
+		return "missing_signature"
+	case errors.Is(err, ErrInvalidSignature):
+		return "invalid_signature"
+	case errors.Is(err, ErrBodyTooLarge):
+		return "body_too_large"
+	default:
+		// Invalid payloads and errors reading the body
+		return "invalid_payload"
+	}
+}
@@ -0,0 +1,227 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/PChaparro/bold-co-sdk/src/definitions"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSecretKey = "test-secret-key"
+
+const testNotification = `{
+	"id": "2b8b4ac0-2d7d-4f4b-8b0d-0c1e5d3c8f6a",
+	"type": "SALE_APPROVED",
+	"subject": "QF7QTCFHG0",
+	"source": "/payments",
+	"spec_version": "1.0",
+	"time": 1715000000000000000,
+	"data": {
+		"payment_id": "QF7QTCFHG0",
+		"merchant_id": "CKKA859CGE",
+		"created_at": "2024-05-06T10:00:00-05:00",
+		"amount": {
+			"currency": "COP",
+			"total": 59900,
+			"taxes": [{"base": 50336, "type": "VAT", "value": 9564}],
+			"tip": 0
+		},
+		"card": {
+			"capture_mode": "CHIP",
+			"franchise": "VISA",
+			"cardholder_name": "JOHN DOE",
+			"terminal_id": "N860W000000"
+		},
+		"user_id": "100",
+		"payment_method": "CARD",
+		"bold_code": "AB12",
+		"metadata": {"reference": "d9b10690-981d-494d-bcb0-66a1dacab51d"}
+	},
+	"datacontenttype": "application/json"
+}`
+
+// newNotificationRequest returns a notification request signed with the given secret key.
+func newNotificationRequest(body string, secretKey string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/webhooks/bold", strings.NewReader(body))
+	req.Header.Set(SignatureHeader, Sign([]byte(body), secretKey))
+	return req
+}
+
+// metricsFunc adapts a function to the Metrics interface.
+type metricsFunc func(reason string)
+
+func (f metricsFunc) ObserveVerificationFailure(reason string) { f(reason) }
+
+func TestSignature(t *testing.T) {
+	body := []byte(`{"id":"1"}`)
+
+	t.Run("valid signature", func(t *testing.T) {
+		signature := Sign(body, testSecretKey)
+		assert.Len(t, signature, 64)
+		assert.NoError(t, VerifySignature(body, signature, testSecretKey))
+		assert.NoError(t, VerifySignature(body, strings.ToUpper(signature), testSecretKey))
+	})
+
+	t.Run("invalid signature", func(t *testing.T) {
+		assert.ErrorIs(t, VerifySignature(body, Sign(body, "other-secret"), testSecretKey), ErrInvalidSignature)
+		assert.ErrorIs(t, VerifySignature([]byte(`{"id":"2"}`), Sign(body, testSecretKey), testSecretKey), ErrInvalidSignature)
+	})
+
+	t.Run("missing signature", func(t *testing.T) {
+		assert.ErrorIs(t, VerifySignature(body, "", testSecretKey), ErrMissingSignature)
+	})
+}
+
+func TestHandler(t *testing.T) {
+	t.Run("valid notification", func(t *testing.T) {
+		var received *definitions.WebhookNotification
+		handler := NewHandler(HandlerConfig{
+			SecretKey: testSecretKey,
+			OnEvent: func(ctx context.Context, notification *definitions.WebhookNotification) error {
+				received = notification
+				return nil
+			},
+		})
+
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, newNotificationRequest(testNotification, testSecretKey))
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		require.NotNil(t, received)
+		assert.Equal(t, "2b8b4ac0-2d7d-4f4b-8b0d-0c1e5d3c8f6a", received.ID)
+		assert.Equal(t, "QF7QTCFHG0", received.Data.PaymentID)
+		assert.Equal(t, "d9b10690-981d-494d-bcb0-66a1dacab51d", received.Data.Metadata.Reference)
+	})
+
+	cases := []struct {
+		name       string
+		req        func() *http.Request
+		onEvent    EventHandlerFunc
+		statusCode int
+		err        error
+		reason     string
+	}{
+		{
+			name:       "invalid signature",
+			req:        func() *http.Request { return newNotificationRequest(testNotification, "other-secret") },
+			statusCode: http.StatusUnauthorized,
+			err:        ErrInvalidSignature,
+			reason:     "invalid_signature",
+		},
+		{
+			name: "missing signature",
+			req: func() *http.Request {
+				return httptest.NewRequest(http.MethodPost, "/webhooks/bold", strings.NewReader(testNotification))
+			},
+			statusCode: http.StatusUnauthorized,
+			err:        ErrMissingSignature,
+			reason:     "missing_signature",
+		},
+		{
+			name:       "invalid payload",
+			req:        func() *http.Request { return newNotificationRequest(`{"id":`, testSecretKey) },
+			statusCode: http.StatusBadRequest,
+			err:        ErrInvalidPayload,
+			reason:     "invalid_payload",
+		},
+		{
+			name: "body too large",
+			req: func() *http.Request {
+				return newNotificationRequest(strings.Repeat(" ", DefaultMaxBodyBytes+1), testSecretKey)
+			},
+			statusCode: http.StatusRequestEntityTooLarge,
+			err:        ErrBodyTooLarge,
+			reason:     "body_too_large",
+		},
+		{
+			name: "method not allowed",
+			req: func() *http.Request {
+				return httptest.NewRequest(http.MethodGet, "/webhooks/bold", nil)
+			},
+			statusCode: http.StatusMethodNotAllowed,
+		},
+		{
+			name: "callback error",
+			req:  func() *http.Request { return newNotificationRequest(testNotification, testSecretKey) },
+			onEvent: func(ctx context.Context, notification *definitions.WebhookNotification) error {
+				return errors.New("database unavailable")
+			},
+			statusCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var reported error
+			var reasons []string
+			handler := NewHandler(HandlerConfig{
+				SecretKey: testSecretKey,
+				OnEvent:   tc.onEvent,
+				OnError:   func(r *http.Request, err error) { reported = err },
+				Metrics:   metricsFunc(func(reason string) { reasons = append(reasons, reason) }),
+			})
+
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, tc.req())
+
+			assert.Equal(t, tc.statusCode, recorder.Code)
+			require.Error(t, reported)
+			if tc.err != nil {
+				assert.ErrorIs(t, reported, tc.err)
+			}
+			if tc.reason != "" {
+				assert.Equal(t, []string{tc.reason}, reasons)
+			} else {
+				assert.Empty(t, reasons)
+			}
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	t.Run("typed notification", func(t *testing.T) {
+		body := []byte(testNotification)
+		notification, err := Parse(body, Sign(body, testSecretKey), testSecretKey)
+		require.NoError(t, err)
+
+		assert.Equal(t, definitions.WebhookEventTypeSaleApproved, notification.Type)
+		assert.True(t, notification.Type.IsKnown())
+		assert.Equal(t, "CKKA859CGE", notification.Data.MerchantID)
+		assert.Equal(t, definitions.PaymentMethodCard, notification.Data.PaymentMethod)
+
+		// Amount
+		assert.Equal(t, definitions.CurrencyTypeCOP, notification.Data.Amount.Currency)
+		assert.Equal(t, float64(59900), notification.Data.Amount.Total)
+		require.Len(t, notification.Data.Amount.Taxes, 1)
+		assert.Equal(t, definitions.TaxTypeIVA, notification.Data.Amount.Taxes[0].Type)
+		assert.Equal(t, float64(9564), notification.Data.Amount.Taxes[0].Value)
+
+		// Card
+		require.NotNil(t, notification.Data.Card)
+		assert.Equal(t, "VISA", notification.Data.Card.Franchise)
+
+		// Metadata
+		_, isPaymentLink := notification.Data.Metadata.PaymentLinkID()
+		assert.False(t, isPaymentLink)
+	})
+
+	t.Run("payment link notification with unknown event type", func(t *testing.T) {
+		body := []byte(`{"id":"1","type":"SALE_REFUNDED","data":{"payment_method":"PSE","metadata":{"reference":"LNK_H7S4XKJ9WQ"}}}`)
+		notification, err := Parse(body, Sign(body, testSecretKey), testSecretKey)
+		require.NoError(t, err)
+
+		assert.Equal(t, definitions.WebhookEventType("SALE_REFUNDED"), notification.Type)
+		assert.False(t, notification.Type.IsKnown())
+		assert.Nil(t, notification.Data.Card)
+
+		paymentLinkID, isPaymentLink := notification.Data.Metadata.PaymentLinkID()
+		assert.True(t, isPaymentLink)
+		assert.Equal(t, "LNK_H7S4XKJ9WQ", paymentLinkID)
+	})
+}
@@ -0,0 +1,144 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/PChaparro/bold-co-sdk/src/definitions"
+)
+
+var (
+	// ErrNotificationInProgress is returned when a notification is delivered
+	// again while a previous delivery is still being processed.
+	ErrNotificationInProgress = errors.New("webhook: notification is already being processed")
+
+	// ErrHandlerPanic is returned when an event handler panics.
+	ErrHandlerPanic = errors.New("webhook: event handler panicked")
+)
+
+// RouterConfig contains the configuration options for the Router.
+type RouterConfig struct {
+	// DedupStore keeps track of the processed notifications so each one is
+	// processed at most once. If not provided, notifications are not deduplicated.
+	DedupStore DedupStore
+}
+
+// Router dispatches the notifications to the handlers registered for their
+// event type. Use its Handle method as the HandlerConfig.OnEvent callback.
+type Router struct {
+	config    RouterConfig
+	mu        sync.RWMutex
+	handlers  map[definitions.WebhookEventType]EventHandlerFunc
+	unhandled EventHandlerFunc
+}
+
+// NewRouter creates a new instance of the Router.
+func NewRouter(config RouterConfig) *Router {
+	return &Router{
+		config:   config,
+		handlers: make(map[definitions.WebhookEventType]EventHandlerFunc),
+	}
+}
+
+// On registers the handler for the given event type, replacing the previous one.
+func (r *Router) On(eventType definitions.WebhookEventType, handler EventHandlerFunc) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.handlers[eventType] = handler
+}
+
+// OnSaleApproved registers the handler for approved sales.
+func (r *Router) OnSaleApproved(handler EventHandlerFunc) {
+	r.On(definitions.WebhookEventTypeSaleApproved, handler)
+}
+
+// OnSaleRejected registers the handler for rejected sales.
+func (r *Router) OnSaleRejected(handler EventHandlerFunc) {
+	r.On(definitions.WebhookEventTypeSaleRejected, handler)
+}
+
+// OnVoidApproved registers the handler for approved voids.
+func (r *Router) OnVoidApproved(handler EventHandlerFunc) {
+	r.On(definitions.WebhookEventTypeVoidApproved, handler)
+}
+
+// OnVoidRejected registers the handler for rejected voids.
+func (r *Router) OnVoidRejected(handler EventHandlerFunc) {
+	r.On(definitions.WebhookEventTypeVoidRejected, handler)
+}
+
+// OnUnhandled registers the handler for the notifications whose event type
+// has no registered handler (including unknown event types). If not provided,
+// those notifications are acknowledged and ignored.
+func (r *Router) OnUnhandled(handler EventHandlerFunc) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.unhandled = handler
+}
+
+// Handle dispatches the notification to the handler registered for its event
+// type. Duplicated notifications are acknowledged without calling the handler
+// again. Errors and panics in the handler are returned as errors, so the
+// notification is not marked as processed and Bold delivers it again.
+func (r *Router) Handle(ctx context.Context, notification *definitions.WebhookNotification) error {
+	handler := r.handlerFor(notification.Type)
+	if handler == nil {
+		return nil
+	}
+
+	store := r.config.DedupStore
+	if store == nil || notification.ID == "" {
+		return runHandler(ctx, handler, notification)
+	}
+
+	status, err := store.Reserve(ctx, notification.ID)
+	if err != nil {
+		return fmt.Errorf("webhook: failed to reserve notification %s: %w", notification.ID, err)
+	}
+
+	switch status {
+	case DedupStatusProcessed:
+		return nil
+	case DedupStatusInProgress:
+		return ErrNotificationInProgress
+	}
+
+	if err := runHandler(ctx, handler, notification); err != nil {
+		if releaseErr := store.Release(ctx, notification.ID); releaseErr != nil {
+			return errors.Join(err, fmt.Errorf("webhook: failed to release notification %s: %w", notification.ID, releaseErr))
+		}
+		return err
+	}
+
+	if err := store.Complete(ctx, notification.ID); err != nil {
+		return fmt.Errorf("webhook: failed to complete notification %s: %w", notification.ID, err)
+	}
+
+	return nil
+}
+
+// handlerFor returns the handler for the given event type.
+func (r *Router) handlerFor(eventType definitions.WebhookEventType) EventHandlerFunc {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if handler, ok := r.handlers[eventType]; ok {
+		return handler
+	}
+	return r.unhandled
+}
+
+// runHandler calls the handler, recovering from its panics.
+func runHandler(ctx context.Context, handler EventHandlerFunc, notification *definitions.WebhookNotification) (err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("%w: %v", ErrHandlerPanic, recovered)
+		}
+	}()
+
+	return handler(ctx, notification)
+}
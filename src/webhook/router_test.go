@@ -0,0 +1,182 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/PChaparro/bold-co-sdk/src/definitions"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRouter(t *testing.T) {
+	newNotification := func(id string, eventType definitions.WebhookEventType) *definitions.WebhookNotification {
+		return &definitions.WebhookNotification{ID: id, Type: eventType}
+	}
+
+	t.Run("dispatches by event type", func(t *testing.T) {
+		var received []definitions.WebhookEventType
+		record := func(ctx context.Context, notification *definitions.WebhookNotification) error {
+			received = append(received, notification.Type)
+			return nil
+		}
+
+		router := NewRouter(RouterConfig{})
+		router.OnSaleApproved(record)
+		router.OnSaleRejected(record)
+		router.OnVoidApproved(record)
+		router.OnVoidRejected(record)
+
+		for _, eventType := range []definitions.WebhookEventType{
+			definitions.WebhookEventTypeSaleApproved,
+			definitions.WebhookEventTypeSaleRejected,
+			definitions.WebhookEventTypeVoidApproved,
+			definitions.WebhookEventTypeVoidRejected,
+			"UNKNOWN_EVENT",
+		} {
+			require.NoError(t, router.Handle(context.Background(), newNotification("1", eventType)))
+		}
+
+		assert.Equal(t, []definitions.WebhookEventType{
+			definitions.WebhookEventTypeSaleApproved,
+			definitions.WebhookEventTypeSaleRejected,
+			definitions.WebhookEventTypeVoidApproved,
+			definitions.WebhookEventTypeVoidRejected,
+		}, received)
+	})
+
+	t.Run("unhandled events", func(t *testing.T) {
+		var unhandled definitions.WebhookEventType
+		router := NewRouter(RouterConfig{})
+		router.OnUnhandled(func(ctx context.Context, notification *definitions.WebhookNotification) error {
+			unhandled = notification.Type
+			return nil
+		})
+
+		require.NoError(t, router.Handle(context.Background(), newNotification("1", "UNKNOWN_EVENT")))
+		assert.Equal(t, definitions.WebhookEventType("UNKNOWN_EVENT"), unhandled)
+	})
+
+	t.Run("duplicated notifications are processed once", func(t *testing.T) {
+		calls := 0
+		router := NewRouter(RouterConfig{DedupStore: NewMemoryDedupStore(time.Hour)})
+		router.OnSaleApproved(func(ctx context.Context, notification *definitions.WebhookNotification) error {
+			calls++
+			return nil
+		})
+
+		for range 3 {
+			require.NoError(t, router.Handle(context.Background(), newNotification("1", definitions.WebhookEventTypeSaleApproved)))
+		}
+		require.NoError(t, router.Handle(context.Background(), newNotification("2", definitions.WebhookEventTypeSaleApproved)))
+
+		assert.Equal(t, 2, calls)
+	})
+
+	t.Run("failed notifications can be processed again", func(t *testing.T) {
+		calls := 0
+		router := NewRouter(RouterConfig{DedupStore: NewMemoryDedupStore(time.Hour)})
+		router.OnSaleApproved(func(ctx context.Context, notification *definitions.WebhookNotification) error {
+			calls++
+			if calls == 1 {
+				return errors.New("temporary failure")
+			}
+			if calls == 2 {
+				panic("unexpected state")
+			}
+			return nil
+		})
+
+		notification := newNotification("1", definitions.WebhookEventTypeSaleApproved)
+		require.Error(t, router.Handle(context.Background(), notification))
+		require.ErrorIs(t, router.Handle(context.Background(), notification), ErrHandlerPanic)
+		require.NoError(t, router.Handle(context.Background(), notification))
+		require.NoError(t, router.Handle(context.Background(), notification))
+
+		assert.Equal(t, 3, calls)
+	})
+
+	t.Run("concurrent duplicates", func(t *testing.T) {
+		started := make(chan struct{})
+		finish := make(chan struct{})
+		router := NewRouter(RouterConfig{DedupStore: NewMemoryDedupStore(time.Hour)})
+		router.OnSaleApproved(func(ctx context.Context, notification *definitions.WebhookNotification) error {
+			close(started)
+			<-finish
+			return nil
+		})
+
+		notification := newNotification("1", definitions.WebhookEventTypeSaleApproved)
+
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, router.Handle(context.Background(), notification))
+		}()
+
+		<-started
+		assert.ErrorIs(t, router.Handle(context.Background(), notification), ErrNotificationInProgress)
+		close(finish)
+		wg.Wait()
+	})
+
+	t.Run("handler errors produce a non-successful response", func(t *testing.T) {
+		router := NewRouter(RouterConfig{})
+		router.OnSaleApproved(func(ctx context.Context, notification *definitions.WebhookNotification) error {
+			panic("boom")
+		})
+
+		handler := NewHandler(HandlerConfig{SecretKey: testSecretKey, OnEvent: router.Handle})
+
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, newNotificationRequest(testNotification, testSecretKey))
+		assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+	})
+}
+
+func TestMemoryDedupStore(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryDedupStore(50 * time.Millisecond)
+
+	status, err := store.Reserve(ctx, "1")
+	require.NoError(t, err)
+	assert.Equal(t, DedupStatusNew, status)
+
+	status, err = store.Reserve(ctx, "1")
+	require.NoError(t, err)
+	assert.Equal(t, DedupStatusInProgress, status)
+
+	require.NoError(t, store.Complete(ctx, "1"))
+	status, err = store.Reserve(ctx, "1")
+	require.NoError(t, err)
+	assert.Equal(t, DedupStatusProcessed, status)
+
+	// Entries are forgotten after the TTL
+	time.Sleep(60 * time.Millisecond)
+	status, err = store.Reserve(ctx, "1")
+	require.NoError(t, err)
+	assert.Equal(t, DedupStatusNew, status)
+}
+
+func TestMemoryDedupStoreDefaultTTL(t *testing.T) {
+	ctx := context.Background()
+
+	for _, ttl := range []time.Duration{0, -time.Minute} {
+		store := NewMemoryDedupStore(ttl)
+		assert.Equal(t, DefaultDedupTTL, store.ttl)
+
+		status, err := store.Reserve(ctx, "1")
+		require.NoError(t, err)
+		assert.Equal(t, DedupStatusNew, status)
+
+		status, err = store.Reserve(ctx, "1")
+		require.NoError(t, err)
+		assert.Equal(t, DedupStatusInProgress, status, "the notifications are still deduplicated")
+	}
+}
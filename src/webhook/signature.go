@@ -0,0 +1,49 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strings"
+)
+
+// SignatureHeader is the header Bold uses to send the signature of the notification.
+const SignatureHeader = "x-bold-signature"
+
+var (
+	// ErrMissingSignature is returned when the notification does not include a signature.
+	ErrMissingSignature = errors.New("webhook: missing signature")
+
+	// ErrInvalidSignature is returned when the signature of the notification does not match.
+	ErrInvalidSignature = errors.New("webhook: invalid signature")
+)
+
+// Sign computes the signature of a notification body the same way Bold does:
+// the hex-encoded HMAC-SHA256, using the secret key, of the base64-encoded body.
+// Notice that, in the sandbox environment, Bold signs the notifications with an
+// empty secret key.
+func Sign(body []byte, secretKey string) string {
+	encoded := base64.StdEncoding.EncodeToString(body)
+
+	mac := hmac.New(sha256.New, []byte(secretKey))
+	mac.Write([]byte(encoded))
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifySignature verifies, in constant time, that the signature matches the
+// notification body for the given secret key.
+func VerifySignature(body []byte, signature string, secretKey string) error {
+	if signature == "" {
+		return ErrMissingSignature
+	}
+
+	expected := Sign(body, secretKey)
+	if !hmac.Equal([]byte(expected), []byte(strings.ToLower(signature))) {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
@@ -0,0 +1,273 @@
+// Package simulator builds realistic Bold webhook notifications, signs them
+// exactly as Bold does and delivers them to a local receiver, so webhook
+// receivers can be tested without triggering real payments.
+package simulator
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/PChaparro/bold-co-sdk/src/definitions"
+	"github.com/PChaparro/bold-co-sdk/src/webhook"
+)
+
+// Config contains the configuration options for the Simulator.
+type Config struct {
+	// URL is the address of the webhook receiver.
+	URL string
+
+	// SecretKey is the secret key used to sign the notifications.
+	// Bold uses an empty secret key in the sandbox environment.
+	SecretKey string
+
+	// MerchantID is the merchant identifier included in the notifications.
+	// If not provided, a random one is generated.
+	MerchantID string
+
+	// HTTPClient is the client used to deliver the notifications.
+	// If not provided, a client with a 10 seconds timeout is used.
+	HTTPClient *http.Client
+}
+
+// NotificationOptions contains the options to build a notification.
+type NotificationOptions struct {
+	// Type is the event type. If not provided, it defaults to SALE_APPROVED.
+	Type definitions.WebhookEventType
+
+	// PaymentLinkID is the payment link the notification refers to.
+	PaymentLinkID string
+
+	// Reference is the Reference of the payment created through the integrations
+	// API. It is ignored if PaymentLinkID is provided.
+	Reference string
+
+	// PaymentID is the identifier of the payment. If not provided, a random one is
+	// generated. Use the same PaymentID to simulate several events of the same sale.
+	PaymentID string
+
+	// PaymentMethod is the payment method. If not provided, it defaults to PSE for
+	// payment links and CARD for integrations API payments.
+	PaymentMethod definitions.PaymentMethod
+
+	// Total is the total amount of the payment, including taxes and tip.
+	// If not provided, it defaults to 10000.
+	Total float64
+
+	// Taxes is the list of taxes applied to the payment.
+	Taxes []definitions.Tax
+
+	// Tip is the tip amount included in the payment.
+	Tip float64
+}
+
+// DeliveryOptions contains the options to deliver a batch of notifications.
+type DeliveryOptions struct {
+	// Duplicates is the number of extra times each notification is delivered.
+	Duplicates int
+
+	// OutOfOrder delivers the notifications in reverse order.
+	OutOfOrder bool
+
+	// BadSignature signs the notifications with a wrong secret key.
+	BadSignature bool
+}
+
+// DeliveryResult contains the outcome of a single delivery.
+type DeliveryResult struct {
+	// NotificationID is the ID of the delivered notification.
+	NotificationID string
+
+	// Type is the event type of the delivered notification.
+	Type definitions.WebhookEventType
+
+	// Duplicate indicates whether this delivery is a duplicate of a previous one.
+	Duplicate bool
+
+	// StatusCode is the status code returned by the receiver.
+	StatusCode int
+}
+
+// Simulator builds and delivers signed webhook notifications.
+type Simulator struct {
+	config Config
+}
+
+// New creates a new instance of the Simulator.
+func New(config Config) *Simulator {
+	if config.HTTPClient == nil {
+		config.HTTPClient = &http.Client{Timeout: 10 * time.Second}
+	}
+	if config.MerchantID == "" {
+		config.MerchantID = randomCode(10)
+	}
+
+	return &Simulator{
+		config: config,
+	}
+}
+
+// NewNotification builds a realistic notification with the given options.
+func (s *Simulator) NewNotification(options NotificationOptions) *definitions.WebhookNotification {
+	if options.Type == "" {
+		options.Type = definitions.WebhookEventTypeSaleApproved
+	}
+	if options.PaymentID == "" {
+		options.PaymentID = randomCode(10)
+	}
+	if options.Total == 0 {
+		options.Total = 10000
+	}
+
+	reference := options.Reference
+	if options.PaymentLinkID != "" {
+		reference = options.PaymentLinkID
+	}
+
+	if options.PaymentMethod == "" {
+		options.PaymentMethod = definitions.PaymentMethodCard
+		if options.PaymentLinkID != "" {
+			options.PaymentMethod = definitions.PaymentMethodPse
+		}
+	}
+
+	var card *definitions.WebhookNotificationCard
+	if options.PaymentMethod == definitions.PaymentMethodCard {
+		card = &definitions.WebhookNotificationCard{
+			CaptureMode:    "CHIP",
+			Franchise:      "VISA",
+			CardholderName: "JOHN DOE",
+			TerminalID:     randomCode(8),
+		}
+	}
+
+	now := time.Now()
+	return &definitions.WebhookNotification{
+		ID:          randomUUID(),
+		Type:        options.Type,
+		Subject:     options.PaymentID,
+		Source:      "/payments",
+		SpecVersion: "1.0",
+		Time:        now.UnixNano(),
+		Data: definitions.WebhookNotificationData{
+			PaymentID:  options.PaymentID,
+			MerchantID: s.config.MerchantID,
+			CreatedAt:  now.Format(time.RFC3339),
+			Amount: definitions.WebhookNotificationAmount{
+				Currency: definitions.CurrencyTypeCOP,
+				Total:    options.Total,
+				Taxes:    options.Taxes,
+				Tip:      options.Tip,
+			},
+			Card:          card,
+			UserID:        randomCode(6),
+			PaymentMethod: options.PaymentMethod,
+			BoldCode:      randomCode(4),
+			Metadata: definitions.WebhookNotificationMetadata{
+				Reference: reference,
+			},
+		},
+		DataContentType: "application/json",
+	}
+}
+
+// Send delivers a single notification and returns the status code of the receiver.
+func (s *Simulator) Send(ctx context.Context, notification *definitions.WebhookNotification) (int, error) {
+	results, err := s.Deliver(ctx, []*definitions.WebhookNotification{notification}, DeliveryOptions{})
+	if err != nil {
+		return 0, err
+	}
+	return results[0].StatusCode, nil
+}
+
+// Deliver sends the notifications to the receiver according to the given options.
+// It stops at the first delivery that fails without getting a response.
+func (s *Simulator) Deliver(
+	ctx context.Context,
+	notifications []*definitions.WebhookNotification,
+	options DeliveryOptions,
+) ([]DeliveryResult, error) {
+	ordered := slices.Clone(notifications)
+	if options.OutOfOrder {
+		slices.Reverse(ordered)
+	}
+
+	secretKey := s.config.SecretKey
+	if options.BadSignature {
+		secretKey += "-invalid"
+	}
+
+	results := make([]DeliveryResult, 0, len(ordered)*(options.Duplicates+1))
+	for _, notification := range ordered {
+		body, err := json.Marshal(notification)
+		if err != nil {
+			return results, fmt.Errorf("error marshalling notification %s: %w", notification.ID, err)
+		}
+
+		for i := 0; i <= options.Duplicates; i++ {
+			statusCode, err := s.post(ctx, body, webhook.Sign(body, secretKey))
+			if err != nil {
+				return results, fmt.Errorf("error delivering notification %s: %w", notification.ID, err)
+			}
+
+			results = append(results, DeliveryResult{
+				NotificationID: notification.ID,
+				Type:           notification.Type,
+				Duplicate:      i > 0,
+				StatusCode:     statusCode,
+			})
+		}
+	}
+
+	return results, nil
+}
+
+// post sends a signed notification body to the receiver.
+func (s *Simulator) post(ctx context.Context, body []byte, signature string) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.config.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(webhook.SignatureHeader, signature)
+
+	resp, err := s.config.HTTPClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	return resp.StatusCode, nil
+}
+
+// randomCode returns a random uppercase alphanumeric code, similar to the
+// identifiers generated by Bold.
+func randomCode(length int) string {
+	const alphabet = "ABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+
+	code := make([]byte, length)
+	_, _ = rand.Read(code)
+	for i := range code {
+		code[i] = alphabet[int(code[i])%len(alphabet)]
+	}
+	return string(code)
+}
+
+// randomUUID returns a random (version 4) UUID.
+func randomUUID() string {
+	var uuid [16]byte
+	_, _ = rand.Read(uuid[:])
+	uuid[6] = (uuid[6] & 0x0f) | 0x40
+	uuid[8] = (uuid[8] & 0x3f) | 0x80
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", uuid[0:4], uuid[4:6], uuid[6:8], uuid[8:10], uuid[10:16])
+}
@@ -0,0 +1,114 @@
+package simulator
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/PChaparro/bold-co-sdk/src/definitions"
+	"github.com/PChaparro/bold-co-sdk/src/webhook"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSecretKey = "test-secret-key"
+
+func TestSimulator(t *testing.T) {
+	var mu sync.Mutex
+	var processed []*definitions.WebhookNotification
+
+	router := webhook.NewRouter(webhook.RouterConfig{DedupStore: webhook.NewMemoryDedupStore(time.Hour)})
+	router.OnUnhandled(func(ctx context.Context, notification *definitions.WebhookNotification) error {
+		mu.Lock()
+		defer mu.Unlock()
+		processed = append(processed, notification)
+		return nil
+	})
+
+	server := httptest.NewServer(webhook.NewHandler(webhook.HandlerConfig{
+		SecretKey: testSecretKey,
+		OnEvent:   router.Handle,
+	}))
+	defer server.Close()
+
+	simulator := New(Config{URL: server.URL, SecretKey: testSecretKey})
+
+	reset := func() {
+		mu.Lock()
+		defer mu.Unlock()
+		processed = nil
+	}
+
+	t.Run("payment link notification", func(t *testing.T) {
+		reset()
+		notification := simulator.NewNotification(NotificationOptions{PaymentLinkID: "LNK_H7S4XKJ9WQ"})
+
+		statusCode, err := simulator.Send(context.Background(), notification)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, statusCode)
+
+		require.Len(t, processed, 1)
+		assert.Equal(t, definitions.WebhookEventTypeSaleApproved, processed[0].Type)
+		assert.Equal(t, definitions.PaymentMethodPse, processed[0].Data.PaymentMethod)
+		assert.Nil(t, processed[0].Data.Card)
+
+		paymentLinkID, ok := processed[0].Data.Metadata.PaymentLinkID()
+		assert.True(t, ok)
+		assert.Equal(t, "LNK_H7S4XKJ9WQ", paymentLinkID)
+	})
+
+	t.Run("duplicated and out of order deliveries", func(t *testing.T) {
+		reset()
+		sale := simulator.NewNotification(NotificationOptions{
+			Reference: "d9b10690-981d-494d-bcb0-66a1dacab51d",
+			PaymentID: "QF7QTCFHG0",
+			Total:     11900,
+			Taxes:     []definitions.Tax{{Type: definitions.TaxTypeIVA, Base: 10000, Value: 1900}},
+		})
+		void := simulator.NewNotification(NotificationOptions{
+			Type:      definitions.WebhookEventTypeVoidApproved,
+			Reference: "d9b10690-981d-494d-bcb0-66a1dacab51d",
+			PaymentID: "QF7QTCFHG0",
+		})
+
+		results, err := simulator.Deliver(context.Background(), []*definitions.WebhookNotification{sale, void}, DeliveryOptions{
+			Duplicates: 2,
+			OutOfOrder: true,
+		})
+		require.NoError(t, err)
+		require.Len(t, results, 6)
+
+		assert.Equal(t, void.ID, results[0].NotificationID)
+		assert.False(t, results[0].Duplicate)
+		assert.True(t, results[1].Duplicate)
+		assert.Equal(t, sale.ID, results[3].NotificationID)
+		for _, result := range results {
+			assert.Equal(t, http.StatusOK, result.StatusCode)
+		}
+
+		// Each notification is processed once, in the delivery order
+		require.Len(t, processed, 2)
+		assert.Equal(t, definitions.WebhookEventTypeVoidApproved, processed[0].Type)
+		assert.Equal(t, definitions.WebhookEventTypeSaleApproved, processed[1].Type)
+		assert.Equal(t, "QF7QTCFHG0", processed[1].Data.PaymentID)
+		assert.Equal(t, definitions.PaymentMethodCard, processed[1].Data.PaymentMethod)
+		assert.NotNil(t, processed[1].Data.Card)
+		assert.Len(t, processed[1].Data.Amount.Taxes, 1)
+	})
+
+	t.Run("bad signature", func(t *testing.T) {
+		reset()
+		notification := simulator.NewNotification(NotificationOptions{Reference: "ref-1"})
+
+		results, err := simulator.Deliver(context.Background(), []*definitions.WebhookNotification{notification}, DeliveryOptions{
+			BadSignature: true,
+		})
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, http.StatusUnauthorized, results[0].StatusCode)
+		assert.Empty(t, processed)
+	})
+}